@@ -0,0 +1,125 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/conventionalcommit/parser/wrap"
+)
+
+// Builder assembles a commit message from its parts
+type Builder struct {
+	commitType  string
+	scope       string
+	description string
+	breaking    bool
+	body        string
+	notes       []Note
+	width       int
+}
+
+// NewBuilder returns a Builder for a commit with type and description
+func NewBuilder(commitType, description string) *Builder {
+	return &Builder{
+		commitType:  commitType,
+		description: description,
+	}
+}
+
+// NewBuilderFromCommit returns a Builder with the parts of a parsed commit,
+// to render it again, like with wrapped body and footer
+func NewBuilderFromCommit(c *Commit) *Builder {
+	b := NewBuilder(c.Type(), c.Description()).
+		Scope(c.Scope()).
+		Body(c.Body())
+
+	prefix := c.Message()[:c.DescriptionSpan().Start]
+	b.breaking = strings.Contains(prefix, "!:")
+
+	for _, n := range c.Notes() {
+		b.notes = append(b.notes, n)
+	}
+
+	return b
+}
+
+// Scope sets the scope of the commit
+func (b *Builder) Scope(scope string) *Builder {
+	b.scope = scope
+	return b
+}
+
+// Breaking marks the commit as breaking change with a "!" before the colon
+func (b *Builder) Breaking() *Builder {
+	b.breaking = true
+	return b
+}
+
+// Body sets the body of the commit
+func (b *Builder) Body(body string) *Builder {
+	b.body = strings.TrimSpace(body)
+	return b
+}
+
+// Footer adds a footer note separated by ": ", like "Refs: #123"
+func (b *Builder) Footer(token, value string) *Builder {
+	n := newNote(token, value)
+	n.separator = footerSeparatorColon
+	n.isBreaking = isBreakingToken(token)
+	b.notes = append(b.notes, n)
+	return b
+}
+
+// BreakingChange adds a "BREAKING CHANGE" footer note describing the change
+func (b *Builder) BreakingChange(description string) *Builder {
+	return b.Footer(breakingTokenSpace, description)
+}
+
+// Wrap reflows body and footer to lines of at most width columns when
+// rendered, see the wrap package. A width below 1 disables wrapping.
+func (b *Builder) Wrap(width int) *Builder {
+	b.width = width
+	return b
+}
+
+// Header returns the header of the commit
+func (b *Builder) Header() string {
+	var s strings.Builder
+
+	s.WriteString(b.commitType)
+	if b.scope != "" {
+		s.WriteString("(" + b.scope + ")")
+	}
+	if b.breaking {
+		s.WriteString("!")
+	}
+	s.WriteString(": " + b.description)
+
+	return s.String()
+}
+
+// String returns the commit message
+func (b *Builder) String() string {
+	parts := []string{b.Header()}
+
+	if b.body != "" {
+		parts = append(parts, wrap.Body(b.body, b.width))
+	}
+
+	if len(b.notes) > 0 {
+		notes := make([]string, len(b.notes))
+		for i := range b.notes {
+			notes[i] = b.notes[i].String()
+		}
+		parts = append(parts, wrap.Footer(strings.Join(notes, "\n"), b.width))
+	}
+
+	return strings.Join(parts, "\n\n")
+}
+
+// Build parses the commit message with p, or with a default parser if p is nil
+func (b *Builder) Build(p *Parser) (*Commit, error) {
+	if p == nil {
+		p = New()
+	}
+	return p.Parse(b.String())
+}
@@ -0,0 +1,56 @@
+package parser
+
+import (
+	"testing"
+)
+
+func TestBuilder(t *testing.T) {
+	var cases = []struct {
+		builder  *Builder
+		expected string
+	}{
+		{NewBuilder("feat", "add x"), "feat: add x"},
+		{NewBuilder("feat", "add x").Scope("api").Breaking(), "feat(api)!: add x"},
+		{
+			NewBuilder("fix", "handle y").Body("body text").Footer("Refs", "#1").BreakingChange("z changed"),
+			"fix: handle y\n\nbody text\n\nRefs: #1\nBREAKING CHANGE: z changed",
+		},
+		{
+			NewBuilder("docs", "wrap").Body("a long body line that needs wrapping").Footer("Refs", "#1").Wrap(20),
+			"docs: wrap\n\na long body line\nthat needs wrapping\n\nRefs: #1",
+		},
+	}
+
+	for i, tc := range cases {
+		if actual := tc.builder.String(); actual != tc.expected {
+			t.Errorf("case#%d: String() = %q, expected %q", i, actual, tc.expected)
+		}
+
+		if _, err := tc.builder.Build(New(WithMode(ModeStrictV100))); err != nil {
+			t.Errorf("case#%d: Build() failed: %v", i, err)
+		}
+	}
+}
+
+func TestBuilderFromCommit(t *testing.T) {
+	msg := "feat(api)!: add x\n\nthis body is long enough to be wrapped\n\nBREAKING CHANGE: the api changed\nRefs #12"
+
+	c, err := New().Parse(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "feat(api)!: add x\n\nthis body is long\nenough to be wrapped\n\nBREAKING CHANGE: the\n api changed\nRefs #12"
+	b := NewBuilderFromCommit(c).Wrap(20)
+	if actual := b.String(); actual != expected {
+		t.Errorf("String() = %q, expected %q", actual, expected)
+	}
+
+	rebuilt, err := b.Build(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !rebuilt.IsBreakingChange() || len(rebuilt.Notes()) != 2 || rebuilt.Notes()[1].Value() != "12" {
+		t.Errorf("rebuilt commit %+v differs", rebuilt.Notes())
+	}
+}
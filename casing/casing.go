@@ -0,0 +1,193 @@
+// Package casing detects and converts the casing of scopes and descriptions
+package casing
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Case is a letter case convention, like camel case or kebab case
+type Case int
+
+// Supported cases
+const (
+	Lower Case = iota
+	Upper
+	Camel
+	Pascal
+	Kebab
+	Snake
+	Sentence
+	Start
+)
+
+// cases are all supported cases in the order of the constants
+var cases = []Case{Lower, Upper, Camel, Pascal, Kebab, Snake, Sentence, Start}
+
+// caseNames are the commitlint names of the cases
+var caseNames = map[Case]string{
+	Lower:    "lower-case",
+	Upper:    "upper-case",
+	Camel:    "camel-case",
+	Pascal:   "pascal-case",
+	Kebab:    "kebab-case",
+	Snake:    "snake-case",
+	Sentence: "sentence-case",
+	Start:    "start-case",
+}
+
+// String returns the commitlint name of the case, like "kebab-case"
+func (c Case) String() string {
+	if name, ok := caseNames[c]; ok {
+		return name
+	}
+	return "unknown-case"
+}
+
+// ByName returns the case of a commitlint name, like "kebab-case"
+func ByName(name string) (Case, bool) {
+	for c, n := range caseNames {
+		if n == name {
+			return c, true
+		}
+	}
+	return 0, false
+}
+
+// Is reports whether text is in the case. Converting text to the case does
+// not change it.
+func (c Case) Is(text string) bool {
+	return Convert(text, c) == text
+}
+
+// Detect returns all cases text is in, in the order of the constants. A
+// single lower-case word like "api" is in lower, camel, kebab and snake case.
+func Detect(text string) []Case {
+	var detected []Case
+	for _, c := range cases {
+		if c.Is(text) {
+			detected = append(detected, c)
+		}
+	}
+	return detected
+}
+
+// Convert converts text to the case. Lower and upper case convert all
+// letters, and sentence case only the first letter. The other cases split
+// text into words at separators and case changes, like "userAPIClient" into
+// "user", "API" and "Client". Acronyms are preserved by camel, pascal and
+// start case.
+func Convert(text string, c Case) string {
+	switch c {
+	case Lower:
+		return strings.ToLower(text)
+	case Upper:
+		return strings.ToUpper(text)
+	case Sentence:
+		return upperFirst(text)
+	case Camel:
+		words := Words(text)
+		for i, w := range words {
+			switch {
+			case i == 0:
+				words[i] = strings.ToLower(w)
+			case !isAcronym(w):
+				words[i] = upperFirst(strings.ToLower(w))
+			}
+		}
+		return strings.Join(words, "")
+	case Pascal:
+		words := Words(text)
+		for i, w := range words {
+			if !isAcronym(w) {
+				words[i] = upperFirst(strings.ToLower(w))
+			}
+		}
+		return strings.Join(words, "")
+	case Kebab:
+		return strings.ToLower(strings.Join(Words(text), "-"))
+	case Snake:
+		return strings.ToLower(strings.Join(Words(text), "_"))
+	case Start:
+		words := Words(text)
+		for i, w := range words {
+			words[i] = upperFirst(w)
+		}
+		return strings.Join(words, " ")
+	default:
+		return text
+	}
+}
+
+// Words splits text into words. Letters and digits form words, everything
+// else separates them. A word also ends before an upper-case letter following
+// a lower-case letter, and before the last upper-case letter of an acronym
+// followed by a lower-case letter. Digits belong to the preceding word.
+func Words(text string) []string {
+	var words []string
+	runes := []rune(text)
+
+	start := -1
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if start >= 0 {
+				words = append(words, string(runes[start:i]))
+				start = -1
+			}
+			continue
+		}
+
+		if start >= 0 && isWordBoundary(runes, i) {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+
+	if start >= 0 {
+		words = append(words, string(runes[start:]))
+	}
+	return words
+}
+
+// isWordBoundary reports whether a new word starts at runes[i], which is
+// not the first rune of a word
+func isWordBoundary(runes []rune, i int) bool {
+	r, prev := runes[i], runes[i-1]
+	if !unicode.IsUpper(r) {
+		return false
+	}
+
+	// "userAPI", "v2Api"
+	if unicode.IsLower(prev) || unicode.IsDigit(prev) {
+		return true
+	}
+
+	// "APIClient"
+	return unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1])
+}
+
+// isAcronym reports whether the word has multiple letters, all upper-case
+func isAcronym(word string) bool {
+	letters := 0
+	for _, r := range word {
+		if unicode.IsLetter(r) {
+			if !unicode.IsUpper(r) {
+				return false
+			}
+			letters++
+		}
+	}
+	return letters > 1
+}
+
+func upperFirst(text string) string {
+	r, size := utf8.DecodeRuneInString(text)
+	if size == 0 {
+		return text
+	}
+	return string(unicode.ToUpper(r)) + text[size:]
+}
@@ -0,0 +1,88 @@
+package casing
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWords(t *testing.T) {
+	var cases = map[string][]string{
+		"user-api":        {"user", "api"},
+		"user_api":        {"user", "api"},
+		"userAPI":         {"user", "API"},
+		"UserAPIClient":   {"User", "API", "Client"},
+		"APIClient":       {"API", "Client"},
+		"v2Api":           {"v2", "Api"},
+		"utf8 decoder":    {"utf8", "decoder"},
+		"add HTTP/2 push": {"add", "HTTP", "2", "push"},
+		"größe-ändern":    {"größe", "ändern"},
+		"":                nil,
+	}
+
+	for text, expected := range cases {
+		if actual := Words(text); !reflect.DeepEqual(actual, expected) {
+			t.Errorf("Words(%q) = %q, expected %q", text, actual, expected)
+		}
+	}
+}
+
+func TestConvert(t *testing.T) {
+	var cases = []struct {
+		text     string
+		c        Case
+		expected string
+	}{
+		{"UserAPI", Lower, "userapi"},
+		{"user api", Upper, "USER API"},
+		{"UserAPI", Kebab, "user-api"},
+		{"user_api client", Kebab, "user-api-client"},
+		{"UserAPI", Snake, "user_api"},
+		{"user-api", Camel, "userApi"},
+		{"UserAPI", Camel, "userAPI"},
+		{"API client", Camel, "apiClient"},
+		{"user-api", Pascal, "UserApi"},
+		{"userAPI", Pascal, "UserAPI"},
+		{"add HTTP support", Sentence, "Add HTTP support"},
+		{"äpfel", Sentence, "Äpfel"},
+		{"add HTTP support", Start, "Add HTTP Support"},
+		{"userAPI", Start, "User API"},
+	}
+
+	for _, tc := range cases {
+		if actual := Convert(tc.text, tc.c); actual != tc.expected {
+			t.Errorf("Convert(%q, %s) = %q, expected %q", tc.text, tc.c, actual, tc.expected)
+		}
+	}
+}
+
+func TestDetect(t *testing.T) {
+	var cases = map[string][]Case{
+		"api":              {Lower, Camel, Kebab, Snake},
+		"user-api":         {Lower, Kebab},
+		"user_api":         {Lower, Snake},
+		"userAPI":          {Camel},
+		"UserAPI":          {Pascal, Sentence},
+		"API":              {Upper, Pascal, Sentence, Start},
+		"Add x":            {Sentence},
+		"Add HTTP Support": {Sentence, Start},
+		"add x":            {Lower},
+	}
+
+	for text, expected := range cases {
+		if actual := Detect(text); !reflect.DeepEqual(actual, expected) {
+			t.Errorf("Detect(%q) = %v, expected %v", text, actual, expected)
+		}
+	}
+}
+
+func TestByName(t *testing.T) {
+	for _, c := range cases {
+		if actual, ok := ByName(c.String()); !ok || actual != c {
+			t.Errorf("ByName(%q) = %v, %v", c.String(), actual, ok)
+		}
+	}
+
+	if _, ok := ByName("title-case"); ok {
+		t.Error("ByName(\"title-case\") should fail")
+	}
+}
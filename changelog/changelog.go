@@ -0,0 +1,212 @@
+// Package changelog generates changelogs from conventional commits. Commits
+// are grouped by type and scope, breaking changes and deprecations are
+// collected in their own sections, and releases are rendered with
+// text/template templates.
+package changelog
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/conventionalcommit/parser"
+	"github.com/conventionalcommit/parser/forge"
+)
+
+var errConfigParse = "%s: %w"
+
+// Commit is a parsed commit with the metadata of its git commit
+type Commit struct {
+	*parser.Commit
+	// Hash is the git commit hash, empty if unknown
+	Hash string
+	// Author is the author of the commit, empty if unknown
+	Author Person
+}
+
+// Release is a version and the commits it contains
+type Release struct {
+	// Version is the released version, empty for unreleased commits
+	Version string
+	// Date is the release date, zero if unknown
+	Date time.Time
+	// Previous is the version the release follows, used to link the changes
+	// since then. If empty, the next older release rendered with it is used.
+	Previous string
+	// Commits are the commits of the release, newest first like git log
+	Commits []Commit
+}
+
+// TypeConfig configures the changelog section of a commit type
+type TypeConfig struct {
+	// Type is the canonical commit type, like "feat"
+	Type string `yaml:"type"`
+	// Section is the title of the section, like "Features"
+	Section string `yaml:"section"`
+	// Hidden excludes the commits of the type from the changelog. Their
+	// breaking changes and deprecations are listed nevertheless.
+	Hidden bool `yaml:"hidden"`
+}
+
+// Config configures the grouping of commits. It has the format of the
+// "types" of conventional-changelog's .versionrc files.
+type Config struct {
+	// Types are the sections of the changelog in the order they are
+	// rendered. Commits of types not listed are hidden.
+	Types []TypeConfig `yaml:"types"`
+	// DeprecationTokens are the footer tokens of deprecation notes
+	DeprecationTokens []string `yaml:"deprecationTokens"`
+}
+
+// DefaultConfig returns the sections of the conventionalcommits preset of
+// conventional-changelog, showing features, fixes, performance improvements
+// and reverts
+func DefaultConfig() Config {
+	return Config{
+		Types: []TypeConfig{
+			{Type: "feat", Section: "Features"},
+			{Type: "fix", Section: "Bug Fixes"},
+			{Type: "perf", Section: "Performance Improvements"},
+			{Type: "revert", Section: "Reverts"},
+			{Type: "docs", Section: "Documentation", Hidden: true},
+			{Type: "style", Section: "Styles", Hidden: true},
+			{Type: "chore", Section: "Miscellaneous Chores", Hidden: true},
+			{Type: "refactor", Section: "Code Refactoring", Hidden: true},
+			{Type: "test", Section: "Tests", Hidden: true},
+			{Type: "build", Section: "Build System", Hidden: true},
+			{Type: "ci", Section: "Continuous Integration", Hidden: true},
+		},
+		DeprecationTokens: []string{"DEPRECATED", "DEPRECATION"},
+	}
+}
+
+// LoadConfig reads a configuration from a YAML or JSON file, like a
+// .versionrc. Settings missing in the file are taken from DefaultConfig.
+func LoadConfig(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, err
+	}
+
+	var c Config
+	if err := yaml.Unmarshal(data, &c); err != nil {
+		return Config{}, fmt.Errorf(errConfigParse, path, err)
+	}
+
+	defaults := DefaultConfig()
+	if c.Types == nil {
+		c.Types = defaults.Types
+	}
+	if c.DeprecationTokens == nil {
+		c.DeprecationTokens = defaults.DeprecationTokens
+	}
+
+	return c, nil
+}
+
+// Option configures a Generator
+type Option func(*Generator)
+
+// WithConfig sets the grouping configuration, DefaultConfig by default
+func WithConfig(c Config) Option {
+	return func(g *Generator) {
+		g.config = c
+	}
+}
+
+// WithTypeRegistry sets the registry used to resolve type aliases, like
+// "feature" to "feat". parser.DefaultTypeRegistry is used by default.
+func WithTypeRegistry(r *parser.TypeRegistry) Option {
+	return func(g *Generator) {
+		g.registry = r
+	}
+}
+
+// WithTemplate sets the template releases are rendered with, see
+// NewTemplate. The Markdown template is used by default.
+func WithTemplate(t *template.Template) Option {
+	return func(g *Generator) {
+		g.template = t
+	}
+}
+
+// WithLinks links commits, references in descriptions and notes, and the
+// changes of releases to the repository of links. Versions are linked to
+// tags with tagPrefix, like "v".
+func WithLinks(links *forge.Links, tagPrefix string) Option {
+	return func(g *Generator) {
+		g.links = links
+		g.tagPrefix = tagPrefix
+	}
+}
+
+// WithContributors lists the contributors of each release. Contributors
+// are first-time contributors if they authored no commit of the older
+// releases rendered or of the history of the configuration.
+func WithContributors(c ContributorConfig) Option {
+	return func(g *Generator) {
+		g.contributors = &c
+	}
+}
+
+// Generator groups the commits of releases and renders them
+type Generator struct {
+	config       Config
+	registry     *parser.TypeRegistry
+	template     *template.Template
+	links        *forge.Links
+	tagPrefix    string
+	contributors *ContributorConfig
+}
+
+// New returns a Generator configured by opts
+func New(opts ...Option) *Generator {
+	g := &Generator{
+		config: DefaultConfig(),
+	}
+	for _, opt := range opts {
+		opt(g)
+	}
+	if g.registry == nil {
+		g.registry = parser.DefaultTypeRegistry()
+	}
+	if g.template == nil {
+		g.template = template.Must(NewTemplate(MarkdownTemplate))
+	}
+	return g
+}
+
+// Render writes the releases, newest first, with the template of the generator
+func (g *Generator) Render(w io.Writer, releases ...Release) error {
+	notes := make([]*ReleaseNotes, len(releases))
+	for i, r := range releases {
+		var previous []Commit
+		for _, older := range releases[i+1:] {
+			previous = append(previous, older.Commits...)
+		}
+
+		notes[i] = g.notes(r, previous)
+		if notes[i].Previous == "" && i+1 < len(releases) {
+			notes[i].Previous = releases[i+1].Version
+		}
+	}
+
+	t, err := g.template.Clone()
+	if err != nil {
+		return err
+	}
+	return t.Funcs(linkFuncs(g.links, g.tagPrefix)).ExecuteTemplate(w, RootTemplate, notes)
+}
+
+// canonicalType returns the type of c with aliases resolved, in lower case
+func (g *Generator) canonicalType(c Commit) string {
+	if t, ok := g.registry.Lookup(c.Type()); ok {
+		return t.Name
+	}
+	return strings.ToLower(c.Type())
+}
@@ -0,0 +1,248 @@
+package changelog
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/conventionalcommit/parser"
+	"github.com/conventionalcommit/parser/forge"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+// testCommits parses messages into commits with hashes
+func testCommits(t *testing.T, messages ...string) []Commit {
+	t.Helper()
+
+	p := parser.New()
+	commits := make([]Commit, len(messages))
+	for i, msg := range messages {
+		c, err := p.Parse(msg)
+		if err != nil {
+			t.Fatalf("Parse(%q) failed: %v", msg, err)
+		}
+		commits[i] = Commit{Commit: c, Hash: strings.Repeat(string(rune('a'+i)), 40)}
+	}
+	return commits
+}
+
+func testReleases(t *testing.T) []Release {
+	return []Release{
+		{
+			Commits: testCommits(t,
+				"fix(api): handle empty bodies",
+				"docs: document flags",
+			),
+		},
+		{
+			Version: "1.1.0",
+			Date:    time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC),
+			Commits: testCommits(t,
+				"feat(parser): add git trailers mode",
+				"feature: add builder",
+				"feat(lint)!: rename rules",
+				"feat(parser): record footer separators",
+				"fix: keep trailing notes\n\nDEPRECATED: WithFooterSeparator, use WithFooterSeparators",
+				"refactor(lexer): split states\n\nBREAKING CHANGE: Lexer is unexported\nuse Parser instead",
+				"chore: bump deps",
+			),
+		},
+		{
+			Version: "1.0.0",
+			Commits: testCommits(t, "ci: add workflow"),
+		},
+	}
+}
+
+func TestRender(t *testing.T) {
+	var cases = []struct {
+		name     string
+		template string
+	}{
+		{"markdown", MarkdownTemplate},
+		{"plain", PlainTemplate},
+	}
+
+	for _, tc := range cases {
+		g := New(WithTemplate(template.Must(NewTemplate(tc.template))))
+
+		var buf bytes.Buffer
+		if err := g.Render(&buf, testReleases(t)...); err != nil {
+			t.Errorf("%s: Render() failed: %v", tc.name, err)
+			continue
+		}
+
+		golden := filepath.Join("testdata", tc.name+".golden")
+		if *update {
+			if err := os.WriteFile(golden, buf.Bytes(), 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		expected, err := os.ReadFile(golden)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != string(expected) {
+			t.Errorf("%s: Render() =\n%s\nexpected\n%s", tc.name, buf.String(), expected)
+		}
+	}
+}
+
+func TestNotes(t *testing.T) {
+	config := Config{
+		Types: []TypeConfig{
+			{Type: "fix", Section: "Fixes"},
+			{Type: "feat"},
+			{Type: "docs", Hidden: true},
+		},
+		DeprecationTokens: []string{"Deprecated"},
+	}
+	g := New(WithConfig(config))
+
+	n := g.Notes(Release{Commits: testCommits(t,
+		"feat(b): add b",
+		"docs!: drop old guide",
+		"feat: add x\n\ndeprecated: y",
+		"perf: speed up",
+		"feat(a): add a",
+		"bugfix(b): fix b",
+	)})
+
+	var groups []string
+	for _, group := range n.Groups {
+		var scopes []string
+		for _, s := range group.Scopes {
+			scopes = append(scopes, s.Scope+"="+s.Commits[0].Description())
+		}
+		groups = append(groups, group.Title+":"+strings.Join(scopes, ","))
+	}
+
+	expected := []string{"Fixes:b=fix b", "feat:=add x,a=add a,b=add b"}
+	if strings.Join(groups, " ") != strings.Join(expected, " ") {
+		t.Errorf("Notes() groups = %q, expected %q", groups, expected)
+	}
+
+	if len(n.Breaking) != 1 || n.Breaking[0].Text != "drop old guide" {
+		t.Errorf("Notes() breaking = %+v, expected description of hidden docs commit", n.Breaking)
+	}
+	if len(n.Deprecations) != 1 || n.Deprecations[0].Text != "y" {
+		t.Errorf("Notes() deprecations = %+v", n.Deprecations)
+	}
+	if n.IsEmpty() {
+		t.Errorf("IsEmpty() = true")
+	}
+	if !g.Notes(Release{Commits: testCommits(t, "docs: x")}).IsEmpty() {
+		t.Errorf("IsEmpty() of hidden commits = false")
+	}
+}
+
+func TestNewTemplateOverrides(t *testing.T) {
+	tmpl, err := NewTemplate(MarkdownTemplate, `{{define "commit"}}{{.Description}} [{{.Hash}}]{{end}}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	release := Release{Version: "0.1.0", Commits: []Commit{{Commit: testCommits(t, "feat: add x")[0].Commit, Hash: "abc"}}}
+	if err := New(WithTemplate(tmpl)).Render(&buf, release); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "## 0.1.0\n\n### Features\n\n- add x [abc]\n"
+	if buf.String() != expected {
+		t.Errorf("Render() = %q, expected %q", buf.String(), expected)
+	}
+
+	if _, err := NewTemplate(MarkdownTemplate, "{{define"); err == nil {
+		t.Errorf("NewTemplate() with invalid override succeeded")
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".versionrc")
+	data := `{"types": [{"type": "feat", "section": "New"}, {"type": "chore", "hidden": true}]}`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(c.Types) != 2 || c.Types[0].Section != "New" || !c.Types[1].Hidden {
+		t.Errorf("LoadConfig() types = %+v", c.Types)
+	}
+	if len(c.DeprecationTokens) == 0 {
+		t.Errorf("LoadConfig() did not default deprecation tokens")
+	}
+
+	if err := os.WriteFile(path, []byte("types: ["), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadConfig(path); err == nil || !strings.Contains(err.Error(), path) {
+		t.Errorf("LoadConfig() of invalid file = %v", err)
+	}
+}
+
+func TestRenderLinks(t *testing.T) {
+	links, err := forge.FromRemote("git@github.com:o/r.git")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	releases := []Release{
+		{Commits: testCommits(t, "fix: handle #12")},
+		{Version: "1.1.0", Commits: testCommits(t, "feat(api)!: drop v1\n\nBREAKING CHANGE: see #3")},
+		{Version: "1.0.0"},
+	}
+
+	var buf bytes.Buffer
+	if err := New(WithLinks(links, "v")).Render(&buf, releases...); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `## [Unreleased](https://github.com/o/r/compare/v1.1.0...HEAD)
+
+### Bug Fixes
+
+- handle [#12](https://github.com/o/r/issues/12) ([aaaaaaa](https://github.com/o/r/commit/aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa))
+
+## [1.1.0](https://github.com/o/r/compare/v1.0.0...v1.1.0)
+
+### ⚠ BREAKING CHANGES
+
+- **api:** see [#3](https://github.com/o/r/issues/3) ([aaaaaaa](https://github.com/o/r/commit/aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa))
+
+### Features
+
+- **api:** drop v1 ([aaaaaaa](https://github.com/o/r/commit/aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa))
+
+## 1.0.0
+`
+	if buf.String() != expected {
+		t.Errorf("Render() =\n%s\nexpected\n%s", buf.String(), expected)
+	}
+
+	buf.Reset()
+	k := &KeepAChangelog{Config: DefaultKeepAChangelogConfig(), Links: links}
+	if err := k.Render(&buf, releases...); err != nil {
+		t.Fatal(err)
+	}
+	for _, link := range []string{
+		"[Unreleased]: https://github.com/o/r/compare/v1.1.0...HEAD\n",
+		"[1.0.0]: https://github.com/o/r/releases/tag/v1.0.0\n",
+		"- handle [#12](https://github.com/o/r/issues/12) ([aaaaaaa](",
+	} {
+		if !strings.Contains(buf.String(), link) {
+			t.Errorf("Render() does not contain %q:\n%s", link, buf.String())
+		}
+	}
+}
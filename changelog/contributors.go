@@ -0,0 +1,260 @@
+package changelog
+
+import (
+	"os"
+	"sort"
+	"strings"
+)
+
+// Footer tokens crediting people other than the author
+const (
+	coAuthorToken = "Co-authored-by"
+	reviewerToken = "Reviewed-by"
+)
+
+// Person is a git identity
+type Person struct {
+	Name  string
+	Email string
+}
+
+// ParsePerson parses an identity in the form "Name <email>", like the value
+// of a Co-authored-by trailer
+func ParsePerson(s string) (Person, bool) {
+	open := strings.LastIndex(s, "<")
+	end := strings.LastIndex(s, ">")
+	if open < 0 || end < open {
+		name := strings.TrimSpace(s)
+		return Person{Name: name}, name != ""
+	}
+
+	p := Person{
+		Name:  strings.TrimSpace(s[:open]),
+		Email: strings.TrimSpace(s[open+1 : end]),
+	}
+	return p, p.Name != "" || p.Email != ""
+}
+
+// String returns the identity in the form "Name <email>"
+func (p Person) String() string {
+	if p.Email == "" {
+		return p.Name
+	}
+	if p.Name == "" {
+		return "<" + p.Email + ">"
+	}
+	return p.Name + " <" + p.Email + ">"
+}
+
+// key identifies a person by e-mail address, or by name if there is none
+func (p Person) key() string {
+	if p.Email != "" {
+		return strings.ToLower(p.Email)
+	}
+	return strings.ToLower(p.Name)
+}
+
+// Mailmap maps the identities of commits to canonical ones, like git's
+// .mailmap file, see gitmailmap(5)
+type Mailmap struct {
+	entries []mailmapEntry
+}
+
+type mailmapEntry struct {
+	properName  string
+	properEmail string
+	commitName  string
+	commitEmail string
+}
+
+// LoadMailmap reads a .mailmap file
+func LoadMailmap(path string) (*Mailmap, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseMailmap(string(data)), nil
+}
+
+// ParseMailmap parses the content of a .mailmap file. Lines that are not
+// valid entries are ignored, like git does.
+func ParseMailmap(text string) *Mailmap {
+	m := &Mailmap{}
+
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		name1, email1, rest, ok := cutIdentity(line)
+		if !ok {
+			continue
+		}
+
+		name2, email2, _, ok := cutIdentity(rest)
+		if !ok {
+			// Proper Name <commit@email>
+			m.entries = append(m.entries, mailmapEntry{properName: name1, commitEmail: email1})
+			continue
+		}
+
+		m.entries = append(m.entries, mailmapEntry{
+			properName:  name1,
+			properEmail: email1,
+			commitName:  name2,
+			commitEmail: email2,
+		})
+	}
+
+	return m
+}
+
+// cutIdentity splits "Name <email> rest" into its parts
+func cutIdentity(s string) (name, email, rest string, ok bool) {
+	open := strings.Index(s, "<")
+	if open < 0 {
+		return "", "", "", false
+	}
+	end := strings.Index(s[open:], ">")
+	if end < 0 {
+		return "", "", "", false
+	}
+	end += open
+
+	return strings.TrimSpace(s[:open]), strings.TrimSpace(s[open+1 : end]), s[end+1:], true
+}
+
+// Resolve returns the canonical identity of p. Entries matching name and
+// e-mail address take precedence over entries matching the address only.
+func (m *Mailmap) Resolve(p Person) Person {
+	if m == nil {
+		return p
+	}
+
+	var match *mailmapEntry
+	for i := range m.entries {
+		e := &m.entries[i]
+		if !strings.EqualFold(e.commitEmail, p.Email) {
+			continue
+		}
+		if e.commitName != "" && strings.EqualFold(e.commitName, p.Name) {
+			match = e
+			break
+		}
+		if e.commitName == "" && match == nil {
+			match = e
+		}
+	}
+
+	if match == nil {
+		return p
+	}
+	if match.properName != "" {
+		p.Name = match.properName
+	}
+	if match.properEmail != "" {
+		p.Email = match.properEmail
+	}
+	return p
+}
+
+// ContributorConfig configures the contributors of releases
+type ContributorConfig struct {
+	// Mailmap maps identities to canonical ones
+	Mailmap *Mailmap
+	// Reviewers includes the people of Reviewed-by trailers
+	Reviewers bool
+	// History are the commits before the rendered releases. Authors and
+	// co-authors of earlier commits are not first-time contributors.
+	History []Commit
+}
+
+// Contributor is a person who contributed to a release
+type Contributor struct {
+	Person
+	// Commits is the number of commits authored or co-authored
+	Commits int
+	// Reviews is the number of commits reviewed
+	Reviews int
+	// FirstTime is true if the person authored or co-authored no commit
+	// before the release
+	FirstTime bool
+}
+
+// Contributors returns the authors, co-authors and, if enabled, reviewers
+// of the commits, deduplicated by e-mail address and sorted by name.
+// Authors and co-authors not found in previous are first-time contributors.
+func (c ContributorConfig) Contributors(commits, previous []Commit) []Contributor {
+	known := make(map[string]bool)
+	for _, commit := range previous {
+		for _, p := range c.authors(commit) {
+			known[p.key()] = true
+		}
+	}
+
+	var contributors []Contributor
+	index := make(map[string]int)
+	add := func(p Person) *Contributor {
+		k := p.key()
+		i, ok := index[k]
+		if !ok {
+			i = len(contributors)
+			index[k] = i
+			contributors = append(contributors, Contributor{Person: p})
+		}
+		return &contributors[i]
+	}
+
+	for _, commit := range commits {
+		for _, p := range c.authors(commit) {
+			contributor := add(p)
+			contributor.Commits++
+			contributor.FirstTime = !known[p.key()]
+		}
+
+		if c.Reviewers {
+			for _, p := range c.trailerPeople(commit, reviewerToken) {
+				add(p).Reviews++
+			}
+		}
+	}
+
+	sort.SliceStable(contributors, func(i, j int) bool {
+		return strings.ToLower(contributors[i].Name) < strings.ToLower(contributors[j].Name)
+	})
+	return contributors
+}
+
+// authors returns the author and co-authors of a commit, each once
+func (c ContributorConfig) authors(commit Commit) []Person {
+	var people []Person
+	if commit.Author != (Person{}) {
+		people = append(people, c.Mailmap.Resolve(commit.Author))
+	}
+
+	for _, p := range c.trailerPeople(commit, coAuthorToken) {
+		duplicate := false
+		for _, existing := range people {
+			duplicate = duplicate || existing.key() == p.key()
+		}
+		if !duplicate {
+			people = append(people, p)
+		}
+	}
+	return people
+}
+
+// trailerPeople returns the people of the footer notes with the token
+func (c ContributorConfig) trailerPeople(commit Commit, token string) []Person {
+	var people []Person
+	for _, note := range commit.Notes() {
+		if !strings.EqualFold(note.Token(), token) {
+			continue
+		}
+		if p, ok := ParsePerson(note.Value()); ok {
+			people = append(people, c.Mailmap.Resolve(p))
+		}
+	}
+	return people
+}
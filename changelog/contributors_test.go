@@ -0,0 +1,176 @@
+package changelog
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testMailmap = `# comment
+Jane Doe <jane@example.com>
+<john@example.com> <john@old.example.com>
+Jane Doe <jane@example.com> <jd@laptop.local>
+Joe Smith <joe@example.com> Joe <joe@shared.example.com>
+Other Joe <other@example.com> <joe@shared.example.com>
+invalid line
+`
+
+func TestMailmapResolve(t *testing.T) {
+	m := ParseMailmap(testMailmap)
+
+	var cases = []struct {
+		person   Person
+		expected Person
+	}{
+		{Person{"jane", "JANE@example.com"}, Person{"Jane Doe", "JANE@example.com"}},
+		{Person{"John", "john@old.example.com"}, Person{"John", "john@example.com"}},
+		{Person{"jd", "jd@laptop.local"}, Person{"Jane Doe", "jane@example.com"}},
+		{Person{"Joe", "joe@shared.example.com"}, Person{"Joe Smith", "joe@example.com"}},
+		{Person{"Joseph", "joe@shared.example.com"}, Person{"Other Joe", "other@example.com"}},
+		{Person{"Max", "max@example.com"}, Person{"Max", "max@example.com"}},
+	}
+
+	for i, tc := range cases {
+		if actual := m.Resolve(tc.person); actual != tc.expected {
+			t.Errorf("case#%d: Resolve(%v) = %v, expected %v", i, tc.person, actual, tc.expected)
+		}
+	}
+
+	var nilMailmap *Mailmap
+	if p := (Person{"a", "b"}); nilMailmap.Resolve(p) != p {
+		t.Errorf("nil Mailmap changed identity")
+	}
+}
+
+func TestLoadMailmap(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".mailmap")
+	if err := os.WriteFile(path, []byte(testMailmap), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := LoadMailmap(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(m.entries) != 5 {
+		t.Errorf("LoadMailmap() has %d entries, expected 5", len(m.entries))
+	}
+
+	if _, err := LoadMailmap(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Errorf("LoadMailmap() of missing file succeeded")
+	}
+}
+
+func TestParsePerson(t *testing.T) {
+	var cases = []struct {
+		value    string
+		expected Person
+		ok       bool
+	}{
+		{"Jane Doe <jane@example.com>", Person{"Jane Doe", "jane@example.com"}, true},
+		{"<jane@example.com>", Person{"", "jane@example.com"}, true},
+		{"Jane Doe", Person{"Jane Doe", ""}, true},
+		{"  ", Person{}, false},
+	}
+
+	for i, tc := range cases {
+		actual, ok := ParsePerson(tc.value)
+		if actual != tc.expected || ok != tc.ok {
+			t.Errorf("case#%d: ParsePerson(%q) = %v, %v, expected %v, %v", i, tc.value, actual, ok, tc.expected, tc.ok)
+		}
+	}
+}
+
+// authored returns commits of messages with authors
+func authored(t *testing.T, authors []Person, messages ...string) []Commit {
+	commits := testCommits(t, messages...)
+	for i := range commits {
+		commits[i].Author = authors[i]
+	}
+	return commits
+}
+
+func TestContributors(t *testing.T) {
+	jane := Person{"Jane Doe", "jane@example.com"}
+	john := Person{"John", "john@old.example.com"}
+	max := Person{"Max", "max@example.com"}
+
+	history := authored(t, []Person{john}, "feat: add x")
+	commits := authored(t, []Person{jane, {"jd", "jd@laptop.local"}, john, max},
+		"feat: add y\n\nCo-authored-by: John <john@example.com>\nReviewed-by: Rita <rita@example.com>",
+		"fix: repair y\n\nCo-authored-by: Jane Doe <JANE@example.com>",
+		"docs: explain y\n\nReviewed-by: Jane Doe <jane@example.com>",
+		"chore: bump y\n\nco-authored-by: Ann <ann@example.com>",
+	)
+
+	var cases = []struct {
+		config   ContributorConfig
+		expected []string
+	}{
+		{
+			ContributorConfig{},
+			[]string{"Ann <ann@example.com> 1 0 true", "Jane Doe <jane@example.com> 2 0 true", "jd <jd@laptop.local> 1 0 true", "John <john@example.com> 1 0 true", "John <john@old.example.com> 1 0 false", "Max <max@example.com> 1 0 true"},
+		},
+		{
+			ContributorConfig{Mailmap: ParseMailmap(testMailmap), Reviewers: true},
+			[]string{"Ann <ann@example.com> 1 0 true", "Jane Doe <jane@example.com> 2 1 true", "John <john@example.com> 2 0 false", "Max <max@example.com> 1 0 true", "Rita <rita@example.com> 0 1 false"},
+		},
+	}
+
+	for i, tc := range cases {
+		var actual []string
+		for _, c := range tc.config.Contributors(commits, history) {
+			actual = append(actual, fmt.Sprintf("%s %d %d %v", c.Person, c.Commits, c.Reviews, c.FirstTime))
+		}
+		if strings.Join(actual, "\n") != strings.Join(tc.expected, "\n") {
+			t.Errorf("case#%d: Contributors() =\n%s\nexpected\n%s", i, strings.Join(actual, "\n"), strings.Join(tc.expected, "\n"))
+		}
+	}
+}
+
+func TestRenderContributors(t *testing.T) {
+	jane := Person{"Jane Doe", "jane@example.com"}
+	john := Person{"John", "john@example.com"}
+
+	releases := []Release{
+		{Version: "1.1.0", Commits: authored(t, []Person{jane, john}, "fix: repair x", "feat: add y")},
+		{Version: "1.0.0", Commits: authored(t, []Person{john}, "feat: add x")},
+	}
+
+	var buf bytes.Buffer
+	if err := New(WithContributors(ContributorConfig{})).Render(&buf, releases...); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `## 1.1.0
+
+### Features
+
+- add y (bbbbbbb)
+
+### Bug Fixes
+
+- repair x (aaaaaaa)
+
+### Contributors
+
+- Jane Doe (first contribution)
+- John
+
+## 1.0.0
+
+### Features
+
+- add x (aaaaaaa)
+
+### Contributors
+
+- John (first contribution)
+`
+	if buf.String() != expected {
+		t.Errorf("Render() =\n%s\nexpected\n%s", buf.String(), expected)
+	}
+}
@@ -0,0 +1,326 @@
+package changelog
+
+import (
+	"io"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/conventionalcommit/parser"
+	"github.com/conventionalcommit/parser/forge"
+)
+
+// KeepAChangelogTemplate renders releases in the format of
+// https://keepachangelog.com/en/1.1.0/. It is executed with a
+// *KeepAChangelogData and defines the templates "header", "release",
+// "section", "entry" and "links".
+const KeepAChangelogTemplate = `
+{{- define "changelog"}}{{template "header" .}}{{range .Releases}}
+{{template "release" .}}{{end}}{{template "links" .Links}}{{end}}
+
+{{- define "header"}}# Changelog
+
+All notable changes to this project will be documented in this file.
+
+The format is based on [Keep a Changelog](https://keepachangelog.com/en/1.1.0/),
+and this project adheres to [Semantic Versioning](https://semver.org/spec/v2.0.0.html).
+{{end}}
+
+{{- define "release"}}## [{{if .Version}}{{.Version}}{{else}}Unreleased{{end}}]{{if not .Date.IsZero}} - {{date .Date}}{{end}}
+{{range .Sections}}
+{{template "section" .}}{{end}}{{end}}
+
+{{- define "section"}}### {{.Title}}
+
+{{range .Entries}}{{template "entry" .}}{{end}}{{end}}
+
+{{- define "entry"}}- {{if .Breaking}}**BREAKING:** {{end}}{{if .Commit.Scope}}**{{.Commit.Scope}}:** {{end}}{{indent 2 (linkify .Text)}}{{if .Commit.Hash}} ({{link (shortHash .Commit.Hash) (commitURL .Commit.Hash)}}){{end}}
+{{end}}
+
+{{- define "links"}}{{if .}}
+{{range .}}[{{.Name}}]: {{.URL}}
+{{end}}{{end}}{{end}}`
+
+// Sections of Keep a Changelog
+const (
+	SectionAdded      = "Added"
+	SectionChanged    = "Changed"
+	SectionDeprecated = "Deprecated"
+	SectionRemoved    = "Removed"
+	SectionFixed      = "Fixed"
+	SectionSecurity   = "Security"
+)
+
+// KeepAChangelogConfig maps commits to the sections of Keep a Changelog
+type KeepAChangelogConfig struct {
+	// Sections are the sections in the order they are rendered. Sections
+	// mapped but not listed follow in the order they first appear.
+	Sections []string
+	// Types maps canonical commit types to sections, commits of other
+	// types are not listed
+	Types map[string]string
+	// Footers maps footer tokens to sections, compared case insensitively
+	// if no token has the exact spelling.
+	// The notes with the tokens are listed in addition to their commit.
+	// Breaking change notes are marked as such. Breaking changes of types
+	// and tokens not mapped are listed in the section of BREAKING CHANGE.
+	Footers map[string]string
+	// TagPrefix is prepended to versions to get their git tag, like "v"
+	TagPrefix string
+	// CompareURL links the changes between two tags, with the placeholders
+	// {from} and {to}, like "https://github.com/o/r/compare/{from}...{to}".
+	// Releases are compared to their Previous version or the next older
+	// release, unreleased changes to HEAD. No links are rendered if it is
+	// empty.
+	CompareURL string
+	// TagURL links the oldest release, with the placeholder {to}, like
+	// "https://github.com/o/r/releases/tag/{to}"
+	TagURL string
+}
+
+// DefaultKeepAChangelogConfig returns the mapping of conventional types to
+// Keep a Changelog: features are added, fixes fixed and performance
+// improvements changed. Breaking changes are listed as changed, DEPRECATED
+// footers as deprecated, and Security footers as security fixes.
+func DefaultKeepAChangelogConfig() KeepAChangelogConfig {
+	return KeepAChangelogConfig{
+		Sections: []string{SectionAdded, SectionChanged, SectionDeprecated, SectionRemoved, SectionFixed, SectionSecurity},
+		Types: map[string]string{
+			"feat":   SectionAdded,
+			"fix":    SectionFixed,
+			"perf":   SectionChanged,
+			"revert": SectionRemoved,
+		},
+		Footers: map[string]string{
+			"BREAKING CHANGE": SectionChanged,
+			"BREAKING-CHANGE": SectionChanged,
+			"DEPRECATED":      SectionDeprecated,
+			"Removed":         SectionRemoved,
+			"Security":        SectionSecurity,
+		},
+		TagPrefix: "v",
+	}
+}
+
+// KeepAChangelog renders releases in the format of keepachangelog.com
+type KeepAChangelog struct {
+	// Config maps commits to sections
+	Config KeepAChangelogConfig
+	// Registry resolves type aliases, parser.DefaultTypeRegistry if nil
+	Registry *parser.TypeRegistry
+	// Template renders the changelog, KeepAChangelogTemplate if nil
+	Template *template.Template
+	// Links link commits, references and versions. The compare and tag
+	// links are used unless CompareURL is configured.
+	Links *forge.Links
+}
+
+// KeepAChangelogData is the data KeepAChangelogTemplate is executed with
+type KeepAChangelogData struct {
+	// Releases are the releases, newest first
+	Releases []KeepAChangelogRelease
+	// Links are the link reference definitions of the release headings
+	Links []Link
+}
+
+// KeepAChangelogRelease is a release with its entries by section
+type KeepAChangelogRelease struct {
+	// Version is the released version, empty for unreleased changes
+	Version string
+	// Date is the release date, zero if unknown
+	Date time.Time
+	// Sections are the non empty sections in the configured order
+	Sections []Section
+}
+
+// Section is a section of a release, like "Added"
+type Section struct {
+	Title   string
+	Entries []Entry
+}
+
+// Entry is a commit or a footer note listed in a section
+type Entry struct {
+	// Commit is the commit of the entry
+	Commit Commit
+	// Text is the description of the commit or the value of the note
+	Text string
+	// Breaking is true for breaking change notes
+	Breaking bool
+}
+
+// Link is a link reference definition, like "[1.0.0]: https://..."
+type Link struct {
+	Name string
+	URL  string
+}
+
+// Render writes the changelog of the releases, newest first
+func (k *KeepAChangelog) Render(w io.Writer, releases ...Release) error {
+	data := &KeepAChangelogData{
+		Links: k.links(releases),
+	}
+	for _, r := range releases {
+		data.Releases = append(data.Releases, KeepAChangelogRelease{
+			Version:  r.Version,
+			Date:     r.Date,
+			Sections: k.Sections(r),
+		})
+	}
+
+	t := k.Template
+	if t == nil {
+		t = template.Must(NewTemplate(KeepAChangelogTemplate))
+	}
+	t, err := t.Clone()
+	if err != nil {
+		return err
+	}
+	return t.Funcs(linkFuncs(k.Links, k.Config.TagPrefix)).ExecuteTemplate(w, RootTemplate, data)
+}
+
+// Sections returns the non empty sections of the release
+func (k *KeepAChangelog) Sections(r Release) []Section {
+	registry := k.Registry
+	if registry == nil {
+		registry = parser.DefaultTypeRegistry()
+	}
+
+	order := append([]string(nil), k.Config.Sections...)
+	entries := make(map[string][]Entry)
+	add := func(section string, e Entry) {
+		if _, ok := entries[section]; !ok && !containsString(order, section) {
+			order = append(order, section)
+		}
+		entries[section] = append(entries[section], e)
+	}
+
+	for _, c := range r.Commits {
+		commitType := strings.ToLower(c.Type())
+		if t, ok := registry.Lookup(commitType); ok {
+			commitType = t.Name
+		}
+		// commits marked breaking in the header only have no note describing it
+		breaking := c.IsBreakingChange() && !hasBreakingNote(c)
+		if section, ok := k.Config.Types[commitType]; ok {
+			add(section, Entry{Commit: c, Text: c.Description(), Breaking: breaking})
+		} else if breaking {
+			add(k.breakingSection(), Entry{Commit: c, Text: c.Description(), Breaking: true})
+		}
+
+		for _, note := range c.Notes() {
+			section, ok := k.footerSection(note.Token())
+			if !ok && note.IsBreakingChange() {
+				section, ok = k.breakingSection(), true
+			}
+			if ok {
+				add(section, Entry{Commit: c, Text: note.Value(), Breaking: note.IsBreakingChange()})
+			}
+		}
+	}
+
+	var sections []Section
+	for _, title := range order {
+		if len(entries[title]) > 0 {
+			sections = append(sections, Section{Title: title, Entries: entries[title]})
+		}
+	}
+	return sections
+}
+
+// breakingSection returns the section of breaking changes of commits whose
+// type or token is not mapped, the section of BREAKING CHANGE footers or
+// SectionChanged
+func (k *KeepAChangelog) breakingSection() string {
+	if section, ok := k.footerSection("BREAKING CHANGE"); ok {
+		return section
+	}
+	return SectionChanged
+}
+
+// footerSection returns the section of notes with the token. A token
+// configured with the same case wins over other spellings, which are tried
+// in sorted order so that the result does not depend on map order.
+func (k *KeepAChangelog) footerSection(token string) (string, bool) {
+	if section, ok := k.Config.Footers[token]; ok {
+		return section, true
+	}
+
+	tokens := make([]string, 0, len(k.Config.Footers))
+	for t := range k.Config.Footers {
+		tokens = append(tokens, t)
+	}
+	sort.Strings(tokens)
+
+	for _, t := range tokens {
+		if strings.EqualFold(t, token) {
+			return k.Config.Footers[t], true
+		}
+	}
+	return "", false
+}
+
+// links returns the compare links of the releases, newest first
+func (k *KeepAChangelog) links(releases []Release) []Link {
+	compare, tag := k.linkFuncs()
+	if compare == nil {
+		return nil
+	}
+
+	var links []Link
+	for i, r := range releases {
+		name, to := r.Version, k.Config.TagPrefix+r.Version
+		if r.Version == "" {
+			name, to = "Unreleased", "HEAD"
+		}
+
+		previous := r.Previous
+		if previous == "" && i+1 < len(releases) {
+			previous = releases[i+1].Version
+		}
+
+		var url string
+		if previous != "" {
+			url = compare(k.Config.TagPrefix+previous, to)
+		} else if r.Version != "" {
+			url = tag(to)
+		}
+
+		if url != "" {
+			links = append(links, Link{Name: name, URL: url})
+		}
+	}
+	return links
+}
+
+// linkFuncs returns the functions generating compare and tag links, from
+// the configured URLs or the forge links, nil if there are none
+func (k *KeepAChangelog) linkFuncs() (compare func(from, to string) string, tag func(to string) string) {
+	if k.Config.CompareURL == "" {
+		if k.Links == nil {
+			return nil, nil
+		}
+		return k.Links.Compare, k.Links.Tag
+	}
+
+	compare = func(from, to string) string {
+		return strings.NewReplacer("{from}", from, "{to}", to).Replace(k.Config.CompareURL)
+	}
+	tag = func(to string) string {
+		if k.Config.TagURL == "" {
+			return ""
+		}
+		return strings.NewReplacer("{to}", to).Replace(k.Config.TagURL)
+	}
+	return compare, tag
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
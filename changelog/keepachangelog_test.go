@@ -0,0 +1,172 @@
+package changelog
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/conventionalcommit/parser"
+)
+
+func TestKeepAChangelog(t *testing.T) {
+	config := DefaultKeepAChangelogConfig()
+	config.CompareURL = "https://github.com/o/r/compare/{from}...{to}"
+	config.TagURL = "https://github.com/o/r/releases/tag/{to}"
+	k := &KeepAChangelog{Config: config}
+
+	releases := append(testReleases(t), Release{
+		Version: "0.9.0",
+		Date:    time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC),
+		Commits: testCommits(t,
+			"feat!: drop v0 API",
+			"fix(auth): escape tokens\n\nSecurity: tokens were logged in plain text",
+			"revert: add cache",
+		),
+	})
+
+	var buf bytes.Buffer
+	if err := k.Render(&buf, releases...); err != nil {
+		t.Fatal(err)
+	}
+
+	golden := filepath.Join("testdata", "keepachangelog.golden")
+	if *update {
+		if err := os.WriteFile(golden, buf.Bytes(), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expected, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != string(expected) {
+		t.Errorf("Render() =\n%s\nexpected\n%s", buf.String(), expected)
+	}
+}
+
+func TestKeepAChangelogLinks(t *testing.T) {
+	var cases = []struct {
+		config   KeepAChangelogConfig
+		releases []Release
+		expected []string
+	}{
+		{
+			KeepAChangelogConfig{},
+			[]Release{{}, {Version: "1.0.0"}},
+			nil,
+		},
+		{
+			KeepAChangelogConfig{CompareURL: "c/{from}..{to}"},
+			[]Release{{}},
+			nil,
+		},
+		{
+			KeepAChangelogConfig{CompareURL: "c/{from}..{to}", TagPrefix: "v"},
+			[]Release{{}, {Version: "1.1.0"}, {Version: "1.0.0"}},
+			[]string{"Unreleased=c/v1.1.0..HEAD", "1.1.0=c/v1.0.0..v1.1.0"},
+		},
+		{
+			KeepAChangelogConfig{CompareURL: "c/{from}..{to}", TagURL: "t/{to}"},
+			[]Release{{Version: "1.1.0"}, {Version: "1.0.0"}},
+			[]string{"1.1.0=c/1.0.0..1.1.0", "1.0.0=t/1.0.0"},
+		},
+	}
+
+	for i, tc := range cases {
+		k := &KeepAChangelog{Config: tc.config}
+
+		var actual []string
+		for _, l := range k.links(tc.releases) {
+			actual = append(actual, l.Name+"="+l.URL)
+		}
+		if strings.Join(actual, " ") != strings.Join(tc.expected, " ") {
+			t.Errorf("case#%d: links() = %q, expected %q", i, actual, tc.expected)
+		}
+	}
+}
+
+func TestKeepAChangelogSections(t *testing.T) {
+	config := KeepAChangelogConfig{
+		Sections: []string{SectionAdded, SectionFixed},
+		Types:    map[string]string{"feat": SectionAdded, "fix": SectionFixed, "docs": "Documentation"},
+		Footers:  map[string]string{"security": SectionSecurity},
+	}
+	k := &KeepAChangelog{Config: config}
+
+	sections := k.Sections(Release{Commits: testCommits(t,
+		"docs: add guide",
+		"fix: escape x\n\nSECURITY: x was not escaped",
+		"feature: add y",
+		"chore: bump",
+	)})
+
+	var actual []string
+	for _, s := range sections {
+		for _, e := range s.Entries {
+			actual = append(actual, s.Title+":"+e.Text)
+		}
+	}
+
+	expected := []string{"Added:add y", "Fixed:escape x", "Documentation:add guide", "Security:x was not escaped"}
+	if strings.Join(actual, ",") != strings.Join(expected, ",") {
+		t.Errorf("Sections() = %q, expected %q", actual, expected)
+	}
+}
+
+func TestKeepAChangelogFooterSection(t *testing.T) {
+	k := &KeepAChangelog{Config: KeepAChangelogConfig{
+		Footers: map[string]string{"Deprecated": SectionDeprecated, "DEPRECATED": SectionRemoved, "deprecated": SectionChanged},
+	}}
+
+	var cases = map[string]string{
+		"Deprecated": SectionDeprecated,
+		"DEPRECATED": SectionRemoved,
+		"deprecated": SectionChanged,
+		"DePrEcAtEd": SectionRemoved,
+	}
+
+	for token, expected := range cases {
+		for i := 0; i < 10; i++ {
+			if actual, ok := k.footerSection(token); !ok || actual != expected {
+				t.Errorf("footerSection(%q) = %q, %v, expected %q", token, actual, ok, expected)
+				break
+			}
+		}
+	}
+}
+
+func TestKeepAChangelogBreakingChanges(t *testing.T) {
+	p := parser.New(parser.WithBreakingChangeTokens("BREAKING"))
+
+	var commits []Commit
+	for _, msg := range []string{
+		"chore!: drop node 12",
+		"refactor: x\n\nBREAKING: rename y",
+		"docs: z\n\nBREAKING CHANGE: remove guide",
+	} {
+		c, err := p.Parse(msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		commits = append(commits, Commit{Commit: c})
+	}
+
+	k := &KeepAChangelog{Config: DefaultKeepAChangelogConfig()}
+
+	var actual []string
+	for _, s := range k.Sections(Release{Commits: commits}) {
+		for _, e := range s.Entries {
+			actual = append(actual, fmt.Sprintf("%s:%s:%v", s.Title, e.Text, e.Breaking))
+		}
+	}
+
+	expected := []string{"Changed:drop node 12:true", "Changed:rename y:true", "Changed:remove guide:true"}
+	if strings.Join(actual, ",") != strings.Join(expected, ",") {
+		t.Errorf("Sections() = %q, expected %q", actual, expected)
+	}
+}
@@ -0,0 +1,173 @@
+package changelog
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// ReleaseNotes are the grouped commits of a release, as passed to templates
+type ReleaseNotes struct {
+	// Version is the released version, empty for unreleased commits
+	Version string
+	// Date is the release date, zero if unknown
+	Date time.Time
+	// Previous is the version the release follows, empty if unknown
+	Previous string
+	// Breaking are the breaking changes of all commits, including hidden ones
+	Breaking []Note
+	// Deprecations are the deprecation notes of all commits
+	Deprecations []Note
+	// Groups are the visible commits by type, in the order of the configuration
+	Groups []Group
+	// Contributors are the people who contributed to the release, if
+	// enabled with WithContributors
+	Contributors []Contributor
+}
+
+// IsEmpty reports whether the release has nothing to list
+func (n *ReleaseNotes) IsEmpty() bool {
+	return len(n.Breaking) == 0 && len(n.Deprecations) == 0 && len(n.Groups) == 0 && len(n.Contributors) == 0
+}
+
+// Group are the commits of a type
+type Group struct {
+	// Type is the canonical commit type
+	Type string
+	// Title is the section title of the type
+	Title string
+	// Commits are all commits of the type, in the order of the release
+	Commits []Commit
+	// Scopes are the commits by scope, commits without scope first and
+	// then sorted by scope
+	Scopes []ScopeGroup
+}
+
+// ScopeGroup are the commits of a type with the same scope
+type ScopeGroup struct {
+	// Scope is the scope of the commits, empty for commits without scope
+	Scope string
+	// Commits are the commits in the order of the release
+	Commits []Commit
+}
+
+// Note is a breaking change or deprecation described by a commit
+type Note struct {
+	// Commit is the commit with the note
+	Commit Commit
+	// Text describes the change
+	Text string
+}
+
+// Notes groups the commits of the release
+func (g *Generator) Notes(r Release) *ReleaseNotes {
+	return g.notes(r, nil)
+}
+
+// notes groups the commits of the release, which follows the previous commits
+func (g *Generator) notes(r Release, previous []Commit) *ReleaseNotes {
+	n := &ReleaseNotes{
+		Version:  r.Version,
+		Date:     r.Date,
+		Previous: r.Previous,
+	}
+
+	byType := make(map[string][]Commit)
+	for _, c := range r.Commits {
+		n.Breaking = append(n.Breaking, breakingNotes(c)...)
+		n.Deprecations = append(n.Deprecations, g.deprecationNotes(c)...)
+
+		t := g.canonicalType(c)
+		byType[t] = append(byType[t], c)
+	}
+
+	for _, tc := range g.config.Types {
+		commits := byType[strings.ToLower(tc.Type)]
+		if tc.Hidden || len(commits) == 0 {
+			continue
+		}
+
+		title := tc.Section
+		if title == "" {
+			title = tc.Type
+		}
+
+		n.Groups = append(n.Groups, Group{
+			Type:    tc.Type,
+			Title:   title,
+			Commits: commits,
+			Scopes:  groupByScope(commits),
+		})
+	}
+
+	if g.contributors != nil {
+		previous = append(previous, g.contributors.History...)
+		n.Contributors = g.contributors.Contributors(r.Commits, previous)
+	}
+
+	return n
+}
+
+// breakingNotes returns the breaking change notes of c, or its description
+// if it is marked breaking in the header only
+func breakingNotes(c Commit) []Note {
+	if !c.IsBreakingChange() {
+		return nil
+	}
+	if !hasBreakingNote(c) {
+		return []Note{{Commit: c, Text: c.Description()}}
+	}
+
+	var notes []Note
+	for _, note := range c.Notes() {
+		if note.IsBreakingChange() {
+			notes = append(notes, Note{Commit: c, Text: note.Value()})
+		}
+	}
+	return notes
+}
+
+// hasBreakingNote reports whether c has a breaking change footer
+func hasBreakingNote(c Commit) bool {
+	for _, note := range c.Notes() {
+		if note.IsBreakingChange() {
+			return true
+		}
+	}
+	return false
+}
+
+// deprecationNotes returns the notes of c with a deprecation token
+func (g *Generator) deprecationNotes(c Commit) []Note {
+	var notes []Note
+	for _, note := range c.Notes() {
+		for _, token := range g.config.DeprecationTokens {
+			if strings.EqualFold(note.Token(), token) {
+				notes = append(notes, Note{Commit: c, Text: note.Value()})
+				break
+			}
+		}
+	}
+	return notes
+}
+
+// groupByScope groups commits by scope, commits without scope first
+func groupByScope(commits []Commit) []ScopeGroup {
+	var groups []ScopeGroup
+	index := make(map[string]int)
+
+	for _, c := range commits {
+		i, ok := index[c.Scope()]
+		if !ok {
+			i = len(groups)
+			index[c.Scope()] = i
+			groups = append(groups, ScopeGroup{Scope: c.Scope()})
+		}
+		groups[i].Commits = append(groups[i].Commits, c)
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].Scope < groups[j].Scope
+	})
+	return groups
+}
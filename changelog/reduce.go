@@ -0,0 +1,374 @@
+package changelog
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/conventionalcommit/parser"
+)
+
+var (
+	// revertLine is added to the body of reverts by git revert
+	revertLine = regexp.MustCompile(`(?m)^This reverts commit ([0-9a-fA-F]{7,40})\b`)
+	// cherryPickLine is added to the body of cherry-picks by git cherry-pick -x
+	cherryPickLine = regexp.MustCompile(`(?m)^\(cherry picked from commit ([0-9a-fA-F]{7,40})\)`)
+)
+
+// Subject prefixes of commits created by git commit --fixup and --squash
+const (
+	fixupPrefix  = "fixup! "
+	squashPrefix = "squash! "
+)
+
+// RawCommit is a commit message before it is parsed, with the metadata of
+// its git commit
+type RawCommit struct {
+	// Hash is the git commit hash, empty if unknown
+	Hash string
+	// Author is the author of the commit, empty if unknown
+	Author Person
+	// Message is the full commit message
+	Message string
+}
+
+// subject returns the first line of the message
+func (c RawCommit) subject() string {
+	return strings.TrimSpace(strings.SplitN(strings.TrimSpace(c.Message), "\n", 2)[0])
+}
+
+// DropReason is the reason a commit is dropped by Reduce
+type DropReason int
+
+const (
+	// DropReverted drops a commit reverted by a later commit of the set
+	DropReverted DropReason = iota
+	// DropRevert drops a revert of a commit of the set, including reverts
+	// of reverts
+	DropRevert
+	// DropFixup drops a fixup! commit, folded into its target
+	DropFixup
+	// DropSquash drops a squash! commit, whose body and footers are added
+	// to the body and footers of its target
+	DropSquash
+	// DropCherryPick drops a cherry-pick of a commit of the set, or a
+	// second cherry-pick of the same commit
+	DropCherryPick
+)
+
+// String returns the name of the reason
+func (r DropReason) String() string {
+	switch r {
+	case DropReverted:
+		return "reverted"
+	case DropRevert:
+		return "revert"
+	case DropFixup:
+		return "fixup"
+	case DropSquash:
+		return "squash"
+	case DropCherryPick:
+		return "cherry-pick"
+	default:
+		return fmt.Sprintf("DropReason(%d)", int(r))
+	}
+}
+
+// Dropped is a commit dropped by Reduce
+type Dropped struct {
+	RawCommit
+	Reason DropReason
+	// By is the hash of the commit the drop is due to: the revert of a
+	// reverted commit, the commit reverted by a revert, the target of a
+	// fixup or squash, or the kept copy of a cherry-pick. It is empty if a
+	// fixup or squash has no target in the set.
+	By string
+}
+
+// String describes the drop, like `1234567 feat: add x: reverted by 89abcde`
+func (d Dropped) String() string {
+	var why string
+	switch d.Reason {
+	case DropReverted:
+		why = "reverted by " + shortHash(d.By)
+	case DropRevert:
+		why = "reverts " + shortHash(d.By)
+	case DropFixup, DropSquash:
+		why = d.Reason.String() + " of " + shortHash(d.By)
+		if d.By == "" {
+			why = d.Reason.String() + " of a commit not in the set"
+		}
+	case DropCherryPick:
+		why = "duplicate of " + shortHash(d.By)
+	}
+
+	if d.Hash == "" {
+		return d.subject() + ": " + why
+	}
+	return shortHash(d.Hash) + " " + d.subject() + ": " + why
+}
+
+// Reduce removes the noise of a commit set, newest first like git log,
+// before its commits are parsed:
+//
+//   - cherry-picks recorded by "(cherry picked from commit ...)" lines are
+//     dropped if the original is in the set, older or newer, and of several
+//     cherry-picks of a commit not in the set the oldest is kept
+//   - fixup! and squash! commits are folded into the older commit with the
+//     same subject, squash! commits adding their body and footers to the
+//     body and footers of the target
+//   - commits and their reverts, recorded by "This reverts commit ..."
+//     lines, cancel each other. In chains of reverts of reverts, the
+//     original commit is kept if it was reverted an even number of times.
+//
+// Reduce returns the kept commits, in their order, and the dropped ones
+// with the reasons they were dropped.
+func Reduce(commits []RawCommit) ([]RawCommit, []Dropped) {
+	r := &reducer{
+		commits: append([]RawCommit(nil), commits...),
+		dropped: make([]*Dropped, len(commits)),
+	}
+
+	r.cherryPicks()
+	r.fixups()
+	r.reverts()
+
+	var kept []RawCommit
+	var dropped []Dropped
+	for i, c := range r.commits {
+		if r.dropped[i] != nil {
+			dropped = append(dropped, *r.dropped[i])
+		} else {
+			kept = append(kept, c)
+		}
+	}
+	return kept, dropped
+}
+
+// reducer holds a commit set, newest first, and why its commits are dropped
+type reducer struct {
+	commits []RawCommit
+	dropped []*Dropped
+}
+
+// drop records that commit i is dropped
+func (r *reducer) drop(i int, reason DropReason, by string) {
+	r.dropped[i] = &Dropped{RawCommit: r.commits[i], Reason: reason, By: by}
+}
+
+// find returns the index of the commit with a full or abbreviated hash, or
+// -1 if it is not in the set
+func (r *reducer) find(hash string) int {
+	for i, c := range r.commits {
+		if sameHash(c.Hash, hash) {
+			return i
+		}
+	}
+	return -1
+}
+
+// cherryPicks drops cherry-picks of commits of the set, even if the
+// original is newer, and all but the oldest cherry-pick of a commit not in
+// the set
+func (r *reducer) cherryPicks() {
+	origins := make([]string, len(r.commits))
+	kept := make(map[string]int)
+
+	for i := len(r.commits) - 1; i >= 0; i-- {
+		origins[i] = r.origin(i, len(r.commits))
+		if origins[i] == "" {
+			// commits without hash are never duplicates
+			continue
+		}
+		k, ok := kept[origins[i]]
+		if !ok || (sameHash(r.commits[i].Hash, origins[i]) && !sameHash(r.commits[k].Hash, origins[i])) {
+			kept[origins[i]] = i
+		}
+	}
+
+	for i := range r.commits {
+		if k, ok := kept[origins[i]]; ok && k != i {
+			r.drop(i, DropCherryPick, r.commits[k].Hash)
+		}
+	}
+}
+
+// origin returns the hash of the commit that commit i was cherry-picked
+// from, following cherry-picks of cherry-picks within the set. depth bounds
+// the recursion for sets with cyclic references.
+func (r *reducer) origin(i, depth int) string {
+	m := cherryPickLine.FindStringSubmatch(r.commits[i].Message)
+	if m == nil || depth == 0 {
+		return strings.ToLower(r.commits[i].Hash)
+	}
+	if j := r.find(m[1]); j >= 0 && j != i {
+		return r.origin(j, depth-1)
+	}
+	return strings.ToLower(m[1])
+}
+
+// fixups folds fixup! and squash! commits into their targets, oldest first
+// so that squashed messages are appended in order
+func (r *reducer) fixups() {
+	for i := len(r.commits) - 1; i >= 0; i-- {
+		if r.dropped[i] != nil {
+			continue
+		}
+
+		subject := r.commits[i].subject()
+		reason := DropFixup
+		switch {
+		case strings.HasPrefix(subject, fixupPrefix):
+		case strings.HasPrefix(subject, squashPrefix):
+			reason = DropSquash
+		default:
+			continue
+		}
+
+		target := r.target(i, trimFixupPrefixes(subject))
+		if target < 0 {
+			r.drop(i, reason, "")
+			continue
+		}
+
+		if reason == DropSquash {
+			r.commits[target].Message = squash(r.commits[target].Message, r.commits[i].Message)
+		}
+		r.drop(i, reason, r.commits[target].Hash)
+	}
+}
+
+// squash returns the target message with the body and footers of the
+// squash! message, after the subject, appended to its body and footers
+func squash(target, message string) string {
+	lines := strings.SplitN(strings.TrimSpace(message), "\n", 2)
+	if len(lines) < 2 || strings.TrimSpace(lines[1]) == "" {
+		return target
+	}
+	rest := strings.TrimSpace(lines[1])
+
+	p := parser.New()
+	c, err := p.Parse(target)
+	if err != nil {
+		return strings.TrimRight(target, "\n") + "\n\n" + rest
+	}
+
+	body, footer := rest, ""
+	// parse the rest with a placeholder header to split body and footers
+	if s, err := p.Parse("squash: -\n\n" + rest); err == nil {
+		body, footer = s.Body(), s.Footer()
+	}
+
+	parts := []string{c.Header()}
+	if b := joinNonEmpty("\n\n", c.Body(), body); b != "" {
+		parts = append(parts, b)
+	}
+	if f := joinNonEmpty("\n", c.Footer(), footer); f != "" {
+		parts = append(parts, f)
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// joinNonEmpty joins the non-empty strings with sep
+func joinNonEmpty(sep string, elems ...string) string {
+	var nonEmpty []string
+	for _, e := range elems {
+		if e != "" {
+			nonEmpty = append(nonEmpty, e)
+		}
+	}
+	return strings.Join(nonEmpty, sep)
+}
+
+// target returns the index of the newest commit older than commit i with
+// the subject, or -1 if there is none
+func (r *reducer) target(i int, subject string) int {
+	for j := i + 1; j < len(r.commits); j++ {
+		if r.dropped[j] == nil && r.commits[j].subject() == subject {
+			return j
+		}
+	}
+	return -1
+}
+
+// trimFixupPrefixes removes the fixup! and squash! prefixes of a subject,
+// including repeated ones of fixups of fixups
+func trimFixupPrefixes(subject string) string {
+	for {
+		switch {
+		case strings.HasPrefix(subject, fixupPrefix):
+			subject = subject[len(fixupPrefix):]
+		case strings.HasPrefix(subject, squashPrefix):
+			subject = subject[len(squashPrefix):]
+		default:
+			return strings.TrimSpace(subject)
+		}
+	}
+}
+
+// reverts cancels commits and their reverts. Reverts of commits in the set
+// are always dropped, while the start of a chain of reverts is dropped if
+// the chain has an odd number of reverts.
+func (r *reducer) reverts() {
+	// reverted[i] is the index of the commit reverted by commit i, or -1
+	reverted := make([]int, len(r.commits))
+	// revertedBy[i] is the index of the oldest revert of commit i, or -1
+	revertedBy := make([]int, len(r.commits))
+	for i := range r.commits {
+		reverted[i], revertedBy[i] = -1, -1
+	}
+
+	for i := len(r.commits) - 1; i >= 0; i-- {
+		if r.dropped[i] != nil {
+			continue
+		}
+		m := revertLine.FindStringSubmatch(r.commits[i].Message)
+		if m == nil {
+			continue
+		}
+
+		j := r.find(m[1])
+		if j > i && r.dropped[j] != nil && r.dropped[j].Reason == DropCherryPick {
+			// a revert of a dropped cherry-pick reverts the kept copy
+			j = r.find(r.dropped[j].By)
+		}
+		if j <= i || r.dropped[j] != nil {
+			continue
+		}
+
+		reverted[i] = j
+		if revertedBy[j] < 0 {
+			revertedBy[j] = i
+		}
+	}
+
+	for i := range r.commits {
+		if reverted[i] >= 0 {
+			r.drop(i, DropRevert, r.commits[reverted[i]].Hash)
+			continue
+		}
+		if revertedBy[i] < 0 {
+			continue
+		}
+
+		reverts := 0
+		for j := revertedBy[i]; j >= 0; j = revertedBy[j] {
+			reverts++
+		}
+		if reverts%2 == 1 {
+			r.drop(i, DropReverted, r.commits[revertedBy[i]].Hash)
+		}
+	}
+}
+
+// sameHash reports whether two full or abbreviated hashes of at least 7
+// characters identify the same commit
+func sameHash(a, b string) bool {
+	if len(a) < 7 || len(b) < 7 {
+		return false
+	}
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	return strings.EqualFold(a, b[:len(a)])
+}
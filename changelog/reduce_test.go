@@ -0,0 +1,204 @@
+package changelog
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/conventionalcommit/parser"
+)
+
+// rawCommits returns commits of messages, newest first, with the hashes
+// "aaaa...", "bbbb..." in that order
+func rawCommits(messages ...string) []RawCommit {
+	commits := make([]RawCommit, len(messages))
+	for i, msg := range messages {
+		commits[i] = RawCommit{Hash: strings.Repeat(string(rune('a'+i)), 40), Message: msg}
+	}
+	return commits
+}
+
+func TestReduce(t *testing.T) {
+	var cases = []struct {
+		commits  []RawCommit
+		kept     []string
+		dropped  []string
+		messages []string
+	}{
+		{
+			// revert pair
+			rawCommits(
+				"Revert \"feat: add x\"\n\nThis reverts commit cccccccccccccccccccccccccccccccccccccccc.",
+				"fix: repair y",
+				"feat: add x",
+			),
+			[]string{"fix: repair y"},
+			[]string{
+				"aaaaaaa Revert \"feat: add x\": reverts ccccccc",
+				"ccccccc feat: add x: reverted by aaaaaaa",
+			},
+			nil,
+		},
+		{
+			// revert of a revert keeps the original, abbreviated hashes
+			rawCommits(
+				"Reapply \"feat: add x\"\n\nThis reverts commit bbbbbbb.",
+				"revert: feat: add x\n\nThis reverts commit ccccccc.",
+				"feat: add x",
+			),
+			[]string{"feat: add x"},
+			[]string{
+				"aaaaaaa Reapply \"feat: add x\": reverts bbbbbbb",
+				"bbbbbbb revert: feat: add x: reverts ccccccc",
+			},
+			nil,
+		},
+		{
+			// three reverts cancel the original
+			rawCommits(
+				"Revert \"Reapply\"\n\nThis reverts commit bbbbbbbbbb.",
+				"Reapply\n\nThis reverts commit cccccccccc.",
+				"Revert \"feat: add x\"\n\nThis reverts commit dddddddddd.",
+				"feat: add x",
+			),
+			nil,
+			[]string{
+				"aaaaaaa Revert \"Reapply\": reverts bbbbbbb",
+				"bbbbbbb Reapply: reverts ccccccc",
+				"ccccccc Revert \"feat: add x\": reverts ddddddd",
+				"ddddddd feat: add x: reverted by ccccccc",
+			},
+			nil,
+		},
+		{
+			// reverts of commits not in the set are kept
+			rawCommits("revert: feat: add x\n\nThis reverts commit 0123456789abcdef.", "fix: repair y"),
+			[]string{"revert: feat: add x", "fix: repair y"},
+			nil,
+			nil,
+		},
+		{
+			// fixups and squashes are folded, in order
+			rawCommits(
+				"squash! feat: add x\n\nSupport y too.",
+				"fixup! fixup! feat: add x",
+				"squash! feat: add x\n\nDocument x.",
+				"fixup! feat: add z",
+				"feat: add x\n\nAdd x.\n",
+			),
+			[]string{"feat: add x"},
+			[]string{
+				"aaaaaaa squash! feat: add x: squash of eeeeeee",
+				"bbbbbbb fixup! fixup! feat: add x: fixup of eeeeeee",
+				"ccccccc squash! feat: add x: squash of eeeeeee",
+				"ddddddd fixup! feat: add z: fixup of a commit not in the set",
+			},
+			[]string{"feat: add x\n\nAdd x.\n\nDocument x.\n\nSupport y too."},
+		},
+		{
+			// squashed bodies go before the footers of the target
+			rawCommits(
+				"squash! feat: y\n\nmore details\n\nCloses #2",
+				"feat: y\n\nRefs: #1",
+			),
+			[]string{"feat: y"},
+			[]string{"aaaaaaa squash! feat: y: squash of bbbbbbb"},
+			[]string{"feat: y\n\nmore details\n\nRefs: #1\nCloses #2"},
+		},
+		{
+			// cherry-picks of commits in the set and repeated cherry-picks
+			rawCommits(
+				"fix: repair y\n\n(cherry picked from commit cccccccccc)",
+				"fix: repair z\n\n(cherry picked from commit 0123456789abcdef)",
+				"fix: repair y",
+				"fix: repair z\n\n(cherry picked from commit 0123456789abcdef)",
+			),
+			[]string{"fix: repair y", "fix: repair z"},
+			[]string{
+				"aaaaaaa fix: repair y: duplicate of ccccccc",
+				"bbbbbbb fix: repair z: duplicate of ddddddd",
+			},
+			nil,
+		},
+		{
+			// the original is kept even if the cherry-pick is older
+			rawCommits(
+				"feat: x",
+				"feat: x\n\n(cherry picked from commit aaaaaaaaaa)",
+			),
+			[]string{"feat: x"},
+			[]string{"bbbbbbb feat: x: duplicate of aaaaaaa"},
+			nil,
+		},
+		{
+			// reverts of dropped cherry-picks revert the kept copy
+			rawCommits(
+				"Revert \"fix: repair y\"\n\nThis reverts commit bbbbbbbbbb.",
+				"fix: repair y\n\n(cherry picked from commit cccccccccc)",
+				"fix: repair y",
+			),
+			nil,
+			[]string{
+				"aaaaaaa Revert \"fix: repair y\": reverts ccccccc",
+				"bbbbbbb fix: repair y: duplicate of ccccccc",
+				"ccccccc fix: repair y: reverted by aaaaaaa",
+			},
+			nil,
+		},
+	}
+
+	for i, tc := range cases {
+		kept, dropped := Reduce(tc.commits)
+
+		var subjects, reasons, messages []string
+		for _, c := range kept {
+			subjects = append(subjects, c.subject())
+			messages = append(messages, c.Message)
+		}
+		for _, d := range dropped {
+			reasons = append(reasons, d.String())
+		}
+
+		if strings.Join(subjects, "\n") != strings.Join(tc.kept, "\n") {
+			t.Errorf("case#%d: kept\n%s\nexpected\n%s", i, strings.Join(subjects, "\n"), strings.Join(tc.kept, "\n"))
+		}
+		if strings.Join(reasons, "\n") != strings.Join(tc.dropped, "\n") {
+			t.Errorf("case#%d: dropped\n%s\nexpected\n%s", i, strings.Join(reasons, "\n"), strings.Join(tc.dropped, "\n"))
+		}
+		if tc.messages != nil && strings.Join(messages, "\n---\n") != strings.Join(tc.messages, "\n---\n") {
+			t.Errorf("case#%d: messages\n%q\nexpected\n%q", i, messages, tc.messages)
+		}
+	}
+}
+
+func TestReduceWithoutHashes(t *testing.T) {
+	commits := []RawCommit{{Message: "feat: x"}, {Message: "fix: y"}}
+
+	kept, dropped := Reduce(commits)
+	if len(kept) != 2 || len(dropped) != 0 {
+		t.Errorf("Reduce() kept %v, dropped %v", kept, dropped)
+	}
+}
+
+func TestReduceKeepsInput(t *testing.T) {
+	commits := rawCommits("squash! feat: add x\n\nMore.", "feat: add x")
+	Reduce(commits)
+
+	if commits[1].Message != "feat: add x" {
+		t.Errorf("Reduce() modified its input: %q", commits[1].Message)
+	}
+}
+
+func TestReduceSquashFooters(t *testing.T) {
+	kept, _ := Reduce(rawCommits("squash! feat: y\n\nmore details", "feat: y\n\nRefs: #1"))
+
+	c, err := parser.New().Parse(kept[0].Message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Body() != "more details" {
+		t.Errorf("Body() = %q, expected %q", c.Body(), "more details")
+	}
+	if notes := c.Notes(); len(notes) != 1 || notes[0].Value() != "#1" {
+		t.Errorf("Notes() = %v, expected Refs: #1", notes)
+	}
+}
@@ -0,0 +1,181 @@
+package changelog
+
+import (
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/conventionalcommit/parser/forge"
+)
+
+// RootTemplate is the name of the template executed with all releases
+const RootTemplate = "changelog"
+
+// shortHashLength is the length of abbreviated commit hashes
+const shortHashLength = 7
+
+// MarkdownTemplate renders releases as Markdown, in the style of
+// conventional-changelog. It defines the templates "changelog", executed
+// with a []*ReleaseNotes, "release", "title", "group", "scope", "commit",
+// "note" and "contributor", which can be redefined by NewTemplate overrides.
+const MarkdownTemplate = `
+{{- define "changelog"}}{{range $i, $r := .}}{{if $i}}
+{{end}}{{template "release" $r}}{{end}}{{end}}
+
+{{- define "release"}}## {{template "title" .}}
+{{if .Breaking}}
+### ⚠ BREAKING CHANGES
+
+{{range .Breaking}}{{template "note" .}}{{end}}{{end}}
+{{- range .Groups}}
+{{template "group" .}}{{end}}
+{{- if .Deprecations}}
+### Deprecations
+
+{{range .Deprecations}}{{template "note" .}}{{end}}{{end}}
+{{- if .Contributors}}
+### Contributors
+
+{{range .Contributors}}{{template "contributor" .}}{{end}}{{end}}{{end}}
+
+{{- define "title"}}{{if .Version}}{{link .Version (compareURL .Previous .Version)}}{{else}}{{link "Unreleased" (compareURL .Previous "")}}{{end}}{{if not .Date.IsZero}} ({{date .Date}}){{end}}{{end}}
+
+{{- define "group"}}### {{.Title}}
+
+{{range .Scopes}}{{template "scope" .}}{{end}}{{end}}
+
+{{- define "scope"}}{{if not .Scope}}{{range .Commits}}- {{template "commit" .}}
+{{end}}{{else if eq (len .Commits) 1}}- **{{.Scope}}:** {{template "commit" index .Commits 0}}
+{{else}}- **{{.Scope}}:**
+{{range .Commits}}  - {{template "commit" .}}
+{{end}}{{end}}{{end}}
+
+{{- define "commit"}}{{linkify .Description}}{{if .Hash}} ({{link (shortHash .Hash) (commitURL .Hash)}}){{end}}{{end}}
+
+{{- define "note"}}- {{if .Commit.Scope}}**{{.Commit.Scope}}:** {{end}}{{indent 2 (linkify .Text)}}{{if .Commit.Hash}} ({{link (shortHash .Commit.Hash) (commitURL .Commit.Hash)}}){{end}}
+{{end}}
+
+{{- define "contributor"}}- {{or .Name .Email}}{{if .FirstTime}} (first contribution){{end}}
+{{end}}`
+
+// PlainTemplate renders releases as plain text. It defines the same
+// templates as MarkdownTemplate.
+const PlainTemplate = `
+{{- define "changelog"}}{{range $i, $r := .}}{{if $i}}
+{{end}}{{template "release" $r}}{{end}}{{end}}
+
+{{- define "release"}}{{template "title" .}}
+{{if .Breaking}}
+BREAKING CHANGES
+{{range .Breaking}}{{template "note" .}}{{end}}{{end}}
+{{- range .Groups}}
+{{template "group" .}}{{end}}
+{{- if .Deprecations}}
+Deprecations
+{{range .Deprecations}}{{template "note" .}}{{end}}{{end}}
+{{- if .Contributors}}
+Contributors
+{{range .Contributors}}{{template "contributor" .}}{{end}}{{end}}{{end}}
+
+{{- define "title"}}{{if .Version}}{{.Version}}{{else}}Unreleased{{end}}{{if not .Date.IsZero}} ({{date .Date}}){{end}}{{end}}
+
+{{- define "group"}}{{.Title}}
+{{range .Scopes}}{{template "scope" .}}{{end}}{{end}}
+
+{{- define "scope"}}{{if not .Scope}}{{range .Commits}}  * {{template "commit" .}}
+{{end}}{{else if eq (len .Commits) 1}}  * {{.Scope}}: {{template "commit" index .Commits 0}}
+{{else}}  * {{.Scope}}:
+{{range .Commits}}    - {{template "commit" .}}
+{{end}}{{end}}{{end}}
+
+{{- define "commit"}}{{.Description}}{{if .Hash}} ({{shortHash .Hash}}){{end}}{{end}}
+
+{{- define "note"}}  * {{if .Commit.Scope}}{{.Commit.Scope}}: {{end}}{{indent 4 .Text}}{{if .Commit.Hash}} ({{shortHash .Commit.Hash}}){{end}}
+{{end}}
+
+{{- define "contributor"}}  * {{or .Name .Email}}{{if .FirstTime}} (first contribution){{end}}
+{{end}}`
+
+// Funcs are the functions available in templates. The link functions
+// return empty URLs and text without links, unless the changelog is
+// rendered with forge links:
+//
+//	commitURL hash        the URL of a commit
+//	compareURL from to    the URL of the changes between two versions, to HEAD if to is empty
+//	linkify text          text with Markdown links of issues, commits and users
+//	link text url         a Markdown link, or text if url is empty
+var Funcs = linkFuncs(nil, "")
+
+// linkFuncs returns the template functions linking to the repository of
+// links, with tags of versions prefixed by tagPrefix
+func linkFuncs(links *forge.Links, tagPrefix string) template.FuncMap {
+	funcs := template.FuncMap{
+		"shortHash":  shortHash,
+		"date":       formatDate,
+		"indent":     indent,
+		"link":       markdownLink,
+		"commitURL":  func(string) string { return "" },
+		"compareURL": func(string, string) string { return "" },
+		"linkify":    func(text string) string { return text },
+	}
+	if links == nil {
+		return funcs
+	}
+
+	funcs["commitURL"] = links.Commit
+	funcs["compareURL"] = func(from, to string) string {
+		if from == "" {
+			return ""
+		}
+		if to == "" {
+			return links.Compare(tagPrefix+from, "HEAD")
+		}
+		return links.Compare(tagPrefix+from, tagPrefix+to)
+	}
+	funcs["linkify"] = links.Linkify
+	return funcs
+}
+
+// markdownLink returns a Markdown link, or text if url is empty
+func markdownLink(text, url string) string {
+	if url == "" {
+		return text
+	}
+	return "[" + text + "](" + url + ")"
+}
+
+// NewTemplate parses a template, like MarkdownTemplate, and overrides that
+// redefine some of its templates, like
+//
+//	{{define "commit"}}{{.Description}} [{{.Hash}}]{{end}}
+func NewTemplate(text string, overrides ...string) (*template.Template, error) {
+	t, err := template.New("templates").Funcs(Funcs).Parse(text)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, o := range overrides {
+		if t, err = t.Parse(o); err != nil {
+			return nil, err
+		}
+	}
+	return t, nil
+}
+
+// shortHash abbreviates a commit hash
+func shortHash(hash string) string {
+	if len(hash) > shortHashLength {
+		return hash[:shortHashLength]
+	}
+	return hash
+}
+
+// formatDate formats a date as YYYY-MM-DD
+func formatDate(t time.Time) string {
+	return t.Format("2006-01-02")
+}
+
+// indent indents all but the first line of s by n spaces
+func indent(n int, s string) string {
+	return strings.ReplaceAll(s, "\n", "\n"+strings.Repeat(" ", n))
+}
@@ -0,0 +1,321 @@
+package changelog
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/conventionalcommit/parser/semver"
+)
+
+// unreleased is the heading name of unreleased changes
+const unreleased = "Unreleased"
+
+var (
+	// releaseHeading matches headings of releases of the Markdown and Keep a
+	// Changelog templates and of conventional-changelog, like "## 1.0.0",
+	// "## [Unreleased]" or "# [1.1.0](https://...) (2021-01-01)"
+	releaseHeading = regexp.MustCompile(`^#{1,2}[ \t]+\[?((?i:unreleased)|v?\d+\.\d+\.\d+[0-9A-Za-z.+-]*)\]?(?:[ \t(\[]|$)`)
+	// linkDefinition matches link reference definitions, like "[1.0.0]: https://..."
+	linkDefinition = regexp.MustCompile(`^\[([^\]]+)\]:[ \t]*(\S+)[ \t]*$`)
+)
+
+// VersionExistsError is returned when updating a changelog with a release
+// that is already in it with other content
+type VersionExistsError struct {
+	Version string
+}
+
+func (e *VersionExistsError) Error() string {
+	return fmt.Sprintf("changelog: version %s already exists", e.Version)
+}
+
+// Document is a Markdown changelog split into releases. Text outside the
+// release sections is kept as it is.
+type Document struct {
+	// Preamble is the text before the first release, like a title
+	Preamble string
+	// Releases are the release sections in the order of the document
+	Releases []ReleaseSection
+	// Links are the link reference definitions at the end of the document
+	Links []Link
+}
+
+// ReleaseSection is the text of a release in a changelog
+type ReleaseSection struct {
+	// Version is the version of the heading, empty for unreleased changes
+	Version string
+	// Text is the section from its heading up to the next release
+	Text string
+}
+
+// ParseDocument splits a changelog into its releases. Release headings are
+// level 1 or 2 headings starting with a version or "Unreleased", optionally
+// in brackets. Headings in fenced code blocks are ignored.
+func ParseDocument(text string) *Document {
+	d := &Document{}
+
+	lines := strings.SplitAfter(text, "\n")
+	lines, d.Links = trailingLinks(lines)
+
+	var section *strings.Builder
+	preamble := &strings.Builder{}
+	current := preamble
+	fence := ""
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case fence != "":
+			if strings.HasPrefix(fenceRun(trimmed), fence) {
+				fence = ""
+			}
+		case fenceRun(trimmed) != "":
+			fence = fenceRun(trimmed)
+		default:
+			if m := releaseHeading.FindStringSubmatch(strings.TrimRight(line, "\r\n")); m != nil {
+				if section != nil {
+					d.Releases[len(d.Releases)-1].Text = section.String()
+				}
+				d.Releases = append(d.Releases, ReleaseSection{Version: versionKey(m[1])})
+				section = &strings.Builder{}
+				current = section
+			}
+		}
+		current.WriteString(line)
+	}
+
+	d.Preamble = preamble.String()
+	if section != nil {
+		d.Releases[len(d.Releases)-1].Text = section.String()
+	}
+	return d
+}
+
+// fenceRun returns the run of at least three backticks or tildes starting
+// a code fence line, or an empty string. A fence is closed by a run of the
+// same character at least as long as the opening one.
+func fenceRun(line string) string {
+	if !strings.HasPrefix(line, "```") && !strings.HasPrefix(line, "~~~") {
+		return ""
+	}
+	n := 3
+	for n < len(line) && line[n] == line[0] {
+		n++
+	}
+	return line[:n]
+}
+
+// trailingLinks splits the link reference definitions, separated by blank
+// lines only, from the end of lines
+func trailingLinks(lines []string) ([]string, []Link) {
+	end := len(lines)
+	start := end
+	for i := end - 1; i >= 0; i-- {
+		trimmed := strings.TrimSpace(lines[i])
+		if trimmed == "" {
+			continue
+		}
+		if !linkDefinition.MatchString(trimmed) {
+			break
+		}
+		start = i
+	}
+
+	var links []Link
+	for _, line := range lines[start:] {
+		if m := linkDefinition.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+			links = append(links, Link{Name: m[1], URL: m[2]})
+		}
+	}
+	return lines[:start], links
+}
+
+// versionKey normalizes the version of a heading or link name, so that
+// "v1.0.0" and "1.0.0" are equal and unreleased changes have no version
+func versionKey(name string) string {
+	if strings.EqualFold(name, unreleased) {
+		return ""
+	}
+	return strings.TrimPrefix(name, "v")
+}
+
+// Release returns the section of a version, "" for unreleased changes
+func (d *Document) Release(version string) (ReleaseSection, bool) {
+	i := d.index(versionKey(version))
+	if i < 0 {
+		return ReleaseSection{}, false
+	}
+	return d.Releases[i], true
+}
+
+func (d *Document) index(version string) int {
+	for i, r := range d.Releases {
+		if r.Version == version {
+			return i
+		}
+	}
+	return -1
+}
+
+// String returns the changelog
+func (d *Document) String() string {
+	var b strings.Builder
+
+	b.WriteString(d.Preamble)
+	if len(d.Releases) > 0 && d.Preamble != "" {
+		ensureBlankLine(&b)
+	}
+
+	for i, r := range d.Releases {
+		b.WriteString(r.Text)
+		if i < len(d.Releases)-1 || len(d.Links) > 0 {
+			ensureBlankLine(&b)
+		}
+	}
+
+	for _, l := range d.Links {
+		fmt.Fprintf(&b, "[%s]: %s\n", l.Name, l.URL)
+	}
+	return b.String()
+}
+
+// ensureBlankLine terminates b with an empty line
+func ensureBlankLine(b *strings.Builder) {
+	s := b.String()
+	switch {
+	case strings.HasSuffix(s, "\n\n"):
+	case strings.HasSuffix(s, "\n"):
+		b.WriteString("\n")
+	default:
+		b.WriteString("\n\n")
+	}
+}
+
+// Updater inserts releases into existing changelogs
+type Updater struct {
+	// Replace replaces releases that exist with other content, instead of
+	// returning a *VersionExistsError. Unreleased changes are always replaced.
+	Replace bool
+}
+
+// Update inserts the releases and links of the rendered changelog into the
+// document. Releases are inserted in the order of their versions, with
+// unreleased changes first. Releases that exist with the same content are
+// left untouched, so updating is idempotent. Everything else of the
+// document, like hand-edited releases, is kept. If a release exists with
+// other content, the document is left unchanged.
+func (u *Updater) Update(d *Document, rendered string) error {
+	update := ParseDocument(rendered)
+
+	for i := range update.Releases {
+		r := &update.Releases[i]
+		r.Text = strings.TrimRight(r.Text, "\n") + "\n"
+
+		if j := d.index(r.Version); j >= 0 && !sameSection(d.Releases[j].Text, r.Text) && r.Version != "" && !u.Replace {
+			return &VersionExistsError{Version: r.Version}
+		}
+	}
+
+	for _, r := range update.Releases {
+		if i := d.index(r.Version); i < 0 {
+			d.insert(r)
+		} else if !sameSection(d.Releases[i].Text, r.Text) {
+			d.Releases[i].Text = r.Text
+		}
+	}
+
+	d.mergeLinks(update.Links)
+	return nil
+}
+
+// UpdateFile updates the changelog file at path with the rendered releases,
+// and reports whether it changed. A missing file is created with the
+// rendered changelog.
+func (u *Updater) UpdateFile(path, rendered string) (bool, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return true, os.WriteFile(path, []byte(rendered), 0o644)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	d := ParseDocument(string(data))
+	if err := u.Update(d, rendered); err != nil {
+		return false, err
+	}
+
+	updated := d.String()
+	if updated == string(data) {
+		return false, nil
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return false, err
+	}
+	return true, os.WriteFile(path, []byte(updated), info.Mode().Perm())
+}
+
+// insert adds a release before the first release with a lower version
+func (d *Document) insert(r ReleaseSection) {
+	at := len(d.Releases)
+
+	if r.Version == "" {
+		at = 0
+	} else if v, err := semver.Parse(r.Version); err != nil {
+		// versions that cannot be compared are inserted first
+		at = 0
+		if len(d.Releases) > 0 && d.Releases[0].Version == "" {
+			at = 1
+		}
+	} else {
+		for i, existing := range d.Releases {
+			if ev, err := semver.Parse(existing.Version); err == nil && ev.Compare(v) < 0 {
+				at = i
+				break
+			}
+		}
+	}
+
+	d.Releases = append(d.Releases, ReleaseSection{})
+	copy(d.Releases[at+1:], d.Releases[at:])
+	d.Releases[at] = r
+}
+
+// mergeLinks replaces the links with the same names and adds the others,
+// ordering the links of releases like the releases
+func (d *Document) mergeLinks(links []Link) {
+	for _, l := range links {
+		replaced := false
+		for i := range d.Links {
+			if versionKey(d.Links[i].Name) == versionKey(l.Name) {
+				d.Links[i].URL = l.URL
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			d.Links = append(d.Links, l)
+		}
+	}
+
+	position := func(l Link) int {
+		if i := d.index(versionKey(l.Name)); i >= 0 {
+			return i
+		}
+		return len(d.Releases)
+	}
+	sort.SliceStable(d.Links, func(i, j int) bool {
+		return position(d.Links[i]) < position(d.Links[j])
+	})
+}
+
+// sameSection reports whether two sections differ in trailing blank lines only
+func sameSection(a, b string) bool {
+	return strings.TrimRight(a, "\n") == strings.TrimRight(b, "\n")
+}
@@ -0,0 +1,183 @@
+package changelog
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseDocumentRoundTrip(t *testing.T) {
+	var cases = []struct {
+		golden   string
+		versions []string
+	}{
+		{"markdown.golden", []string{"", "1.1.0", "1.0.0"}},
+		{"keepachangelog.golden", []string{"", "1.1.0", "1.0.0", "0.9.0"}},
+	}
+
+	for _, tc := range cases {
+		data, err := os.ReadFile(filepath.Join("testdata", tc.golden))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		d := ParseDocument(string(data))
+
+		var versions []string
+		for _, r := range d.Releases {
+			versions = append(versions, r.Version)
+		}
+		if strings.Join(versions, ",") != strings.Join(tc.versions, ",") {
+			t.Errorf("%s: versions %q, expected %q", tc.golden, versions, tc.versions)
+		}
+		if d.String() != string(data) {
+			t.Errorf("%s: String() =\n%s\nexpected\n%s", tc.golden, d.String(), data)
+		}
+	}
+}
+
+func TestParseDocument(t *testing.T) {
+	text := "# Changelog\n\n```md\n## 9.9.9\n```\n\n# [1.1.0](https://x/compare/v1.0.0...v1.1.0) (2021-01-01)\n\n* x\n\n## v1.0.0\n\n* y\n\n[1.0.0]: https://x/1.0.0\n\n[x]: https://x\n"
+	d := ParseDocument(text)
+
+	if !strings.Contains(d.Preamble, "## 9.9.9") {
+		t.Errorf("heading in code block was parsed as release: %+v", d.Releases)
+	}
+	if r, ok := d.Release("v1.1.0"); !ok || !strings.HasPrefix(r.Text, "# [1.1.0](") {
+		t.Errorf("Release(v1.1.0) = %q, %v", r.Text, ok)
+	}
+	if r, ok := d.Release("1.0.0"); !ok || r.Text != "## v1.0.0\n\n* y\n\n" {
+		t.Errorf("Release(1.0.0) = %q, %v", r.Text, ok)
+	}
+	if len(d.Links) != 2 || d.Links[1].Name != "x" {
+		t.Errorf("Links = %+v", d.Links)
+	}
+	if _, ok := d.Release(""); ok {
+		t.Errorf("Release(unreleased) found")
+	}
+
+	// a fence is only closed by a fence at least as long
+	d = ParseDocument("# Changelog\n\n````md\n```\n## 9.9.9\n```\n````\n\n## 1.0.0\n\n* y\n")
+	if len(d.Releases) != 1 || d.Releases[0].Version != "1.0.0" {
+		t.Errorf("heading in nested code block was parsed as release: %+v", d.Releases)
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	k := &KeepAChangelog{Config: DefaultKeepAChangelogConfig()}
+	k.Config.CompareURL = "https://x/compare/{from}...{to}"
+
+	render := func(releases ...Release) string {
+		var buf bytes.Buffer
+		if err := k.Render(&buf, releases...); err != nil {
+			t.Fatal(err)
+		}
+		return buf.String()
+	}
+
+	v100 := Release{Version: "1.0.0", Date: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), Commits: testCommits(t, "feat: add x")}
+	v110 := Release{Version: "1.1.0", Previous: "1.0.0", Date: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), Commits: testCommits(t, "fix: repair x")}
+	v090 := Release{Version: "0.9.0", Commits: testCommits(t, "feat: add w")}
+
+	// a hand edit of 1.0.0 is kept
+	existing := strings.Replace(render(Release{Commits: testCommits(t, "fix: repair x")}, v100), "- add x (aaaaaaa)", "- add x, see the migration guide", 1)
+	d := ParseDocument(existing)
+
+	u := &Updater{}
+	if err := u.Update(d, render(Release{Previous: "1.1.0"}, v110)); err != nil {
+		t.Fatal(err)
+	}
+	// older releases are inserted after newer ones
+	if err := u.Update(d, render(v090)); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `# Changelog
+
+All notable changes to this project will be documented in this file.
+
+The format is based on [Keep a Changelog](https://keepachangelog.com/en/1.1.0/),
+and this project adheres to [Semantic Versioning](https://semver.org/spec/v2.0.0.html).
+
+## [Unreleased]
+
+## [1.1.0] - 2026-02-01
+
+### Fixed
+
+- repair x (aaaaaaa)
+
+## [1.0.0] - 2026-01-01
+
+### Added
+
+- add x, see the migration guide
+
+## [0.9.0]
+
+### Added
+
+- add w (aaaaaaa)
+
+[Unreleased]: https://x/compare/v1.1.0...HEAD
+[1.1.0]: https://x/compare/v1.0.0...v1.1.0
+`
+	if d.String() != expected {
+		t.Errorf("Update() =\n%s\nexpected\n%s", d.String(), expected)
+	}
+
+	// updating again changes nothing
+	if err := u.Update(d, render(Release{Previous: "1.1.0"}, v110)); err != nil || d.String() != expected {
+		t.Errorf("repeated Update() = %v\n%s", err, d.String())
+	}
+
+	// existing versions are not duplicated
+	changed := Release{Version: "1.1.0", Commits: testCommits(t, "fix: repair y")}
+	var verr *VersionExistsError
+	if err := u.Update(d, render(changed)); !errors.As(err, &verr) || verr.Version != "1.1.0" {
+		t.Errorf("Update() of existing version = %v, expected *VersionExistsError", err)
+	}
+
+	// a conflict leaves the document unchanged
+	v120 := Release{Version: "1.2.0", Previous: "1.1.0", Commits: testCommits(t, "feat: add z")}
+	if err := u.Update(d, render(v120, changed)); !errors.As(err, &verr) || d.String() != expected {
+		t.Errorf("Update() with conflict = %v\n%s", err, d.String())
+	}
+
+	u.Replace = true
+	if err := u.Update(d, render(changed)); err != nil {
+		t.Fatal(err)
+	}
+	if r, _ := d.Release("1.1.0"); !strings.Contains(r.Text, "repair y") || strings.Count(d.String(), "## [1.1.0]") != 1 {
+		t.Errorf("Update() did not replace 1.1.0:\n%s", d.String())
+	}
+}
+
+func TestUpdateFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "CHANGELOG.md")
+	u := &Updater{}
+
+	v1 := "# Changelog\n\n## 1.0.0\n\n- x\n"
+	if changed, err := u.UpdateFile(path, v1); err != nil || !changed {
+		t.Fatalf("UpdateFile() of missing file = %v, %v", changed, err)
+	}
+
+	if changed, err := u.UpdateFile(path, "## 1.1.0\n\n- y\n"); err != nil || !changed {
+		t.Fatalf("UpdateFile() = %v, %v", changed, err)
+	}
+	if changed, err := u.UpdateFile(path, "## 1.1.0\n\n- y\n"); err != nil || changed {
+		t.Errorf("repeated UpdateFile() = %v, %v", changed, err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "# Changelog\n\n## 1.1.0\n\n- y\n\n## 1.0.0\n\n- x\n"; string(data) != expected {
+		t.Errorf("UpdateFile() wrote %q, expected %q", data, expected)
+	}
+}
@@ -0,0 +1,402 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/conventionalcommit/parser"
+	"github.com/conventionalcommit/parser/lint"
+	"github.com/conventionalcommit/parser/report"
+	"github.com/conventionalcommit/parser/scope"
+	"github.com/conventionalcommit/parser/width"
+)
+
+// defaultHeaderMax is the header length limit if the configuration has none
+const defaultHeaderMax = 100
+
+var errIncompleteAnswers = errors.New("input ended before all questions were answered")
+
+func runCompose(e *env, args []string) int {
+	fs := flag.NewFlagSet("compose", flag.ContinueOnError)
+	fs.SetOutput(e.stderr)
+	fs.Usage = func() {
+		fmt.Fprintln(e.stderr, "Usage: conventionalcommit compose [flags]")
+		fmt.Fprintln(e.stderr)
+		fmt.Fprintln(e.stderr, "Asks for the parts of a commit and prints the message. If stdin is not a")
+		fmt.Fprintln(e.stderr, "terminal, answers are read from it line by line, with the body ending at")
+		fmt.Fprintln(e.stderr, "an empty line. Exits with 1 if the message is invalid, and with 3 on I/O errors.")
+		fmt.Fprintln(e.stderr)
+		fs.PrintDefaults()
+	}
+
+	var pf parserFlags
+	pf.register(fs)
+	config := fs.String("config", "", "commitlint configuration `file` with scopes and header length, found in the working directory by default")
+	scopes := fs.String("scopes", "", "comma separated `scopes` to complete, in addition to the configured ones")
+	discover := fs.Bool("discover", false, "complete scopes discovered from directories, Go modules and workspaces")
+	headerMax := fs.Int("header-max-length", 0, "header length `limit`, from the configuration or 100 by default")
+	wrapWidth := fs.Int("wrap", 72, "wrap body and footer at `width` columns, 0 to disable")
+	issueToken := fs.String("issue-token", "Refs", "footer `token` of issue references")
+	file := fs.String("file", "", "write the message to `path` instead of stdout")
+
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() > 0 {
+		fs.Usage()
+		return exitUsage
+	}
+
+	settings, err := loadSettings(e, *config)
+	if err != nil {
+		fmt.Fprintf(e.stderr, "conventionalcommit: %v\n", err)
+		var uerr *lint.UnsupportedRulesError
+		if !errors.As(err, &uerr) {
+			return exitIO
+		}
+	}
+
+	c := &composer{
+		prompter:   newPrompter(e),
+		out:        e.stderr,
+		registry:   parser.DefaultTypeRegistry(),
+		scopes:     configuredScopes(settings, *scopes),
+		headerMax:  *headerMax,
+		issueToken: *issueToken,
+	}
+	if c.headerMax <= 0 {
+		c.headerMax = headerLimit(settings)
+	}
+	if *discover {
+		found, err := scope.Discover(e.dir, scope.TopLevelDirs{}, scope.GoModules{}, scope.Workspaces{})
+		if err != nil {
+			fmt.Fprintf(e.stderr, "conventionalcommit: %v\n", err)
+			return exitIO
+		}
+		c.scopes = mergeScopes(c.scopes, found)
+	}
+
+	b, err := c.compose()
+	if errors.Is(err, io.EOF) {
+		err = errIncompleteAnswers
+	}
+	if err != nil {
+		fmt.Fprintf(e.stderr, "conventionalcommit: %v\n", err)
+		return exitIO
+	}
+	b.Wrap(*wrapWidth)
+
+	p := pf.parser(parser.WithTypeRegistry(c.registry))
+	msg := b.String()
+	if _, err := b.Build(p); err != nil {
+		entries := []report.Entry{{Source: "<composed>", Result: lint.New(p).Lint(msg)}}
+		if err := pf.pretty(e).Report(e.stderr, entries); err != nil {
+			return exitIO
+		}
+		return exitCode(errInvalidMessage)
+	}
+
+	if *file != "" {
+		err = os.WriteFile(e.path(*file), []byte(msg+"\n"), 0o644)
+	} else {
+		_, err = fmt.Fprintln(e.stdout, msg)
+	}
+	if err != nil {
+		fmt.Fprintf(e.stderr, "conventionalcommit: %v\n", err)
+		return exitIO
+	}
+	return exitOK
+}
+
+// composer asks for the parts of a commit
+type composer struct {
+	prompter
+	out        io.Writer
+	registry   *parser.TypeRegistry
+	scopes     []string
+	headerMax  int
+	issueToken string
+}
+
+// compose asks for all parts and returns a builder of the commit
+func (c *composer) compose() (*parser.Builder, error) {
+	commitType, err := c.askType()
+	if err != nil {
+		return nil, err
+	}
+	scope, err := c.askScope()
+	if err != nil {
+		return nil, err
+	}
+	description, err := c.askDescription(commitType, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	b := parser.NewBuilder(commitType, description).Scope(scope)
+
+	body, err := c.askBody()
+	if err != nil {
+		return nil, err
+	}
+	b.Body(body)
+
+	breaking, err := c.askBreakingChange()
+	if err != nil {
+		return nil, err
+	}
+	if breaking != "" {
+		b.BreakingChange(breaking)
+	}
+
+	issues, err := c.askIssues()
+	if err != nil {
+		return nil, err
+	}
+	if issues != "" {
+		b.Footer(c.issueToken, issues)
+	}
+
+	return b, nil
+}
+
+// askType lists the registered types and asks for one by number, name or alias
+func (c *composer) askType() (string, error) {
+	types := c.registry.Types()
+
+	names := make([]string, len(types))
+	nameWidth := 0
+	for i, t := range types {
+		names[i] = t.Name
+		if len(t.Name) > nameWidth {
+			nameWidth = len(t.Name)
+		}
+	}
+	for i, t := range types {
+		fmt.Fprintf(c.out, "%3d) %-*s  %s\n", i+1, nameWidth, t.Name, t.Description)
+	}
+
+	q := question{
+		label:    "type",
+		complete: func(input string) []string { return completions(input, names) },
+	}
+	for {
+		answer, err := c.ask(q)
+		if err != nil {
+			return "", err
+		}
+		answer = strings.TrimSpace(answer)
+
+		if n, err := strconv.Atoi(answer); err == nil && n >= 1 && n <= len(types) {
+			return types[n-1].Name, nil
+		}
+		if t, ok := c.registry.Lookup(answer); ok {
+			return t.Name, nil
+		}
+
+		fmt.Fprintf(c.out, "unknown type %q, enter a number or name of the list\n", answer)
+	}
+}
+
+// askScope asks for an optional scope. Known scopes are completed on tab,
+// and unknown ones are completed if they are the prefix of a single known
+// scope, or rejected.
+func (c *composer) askScope() (string, error) {
+	q := question{
+		label:    "scope (optional)",
+		complete: func(input string) []string { return completions(input, c.scopes) },
+	}
+	for {
+		answer, err := c.ask(q)
+		if err != nil {
+			return "", err
+		}
+		answer = strings.TrimSpace(answer)
+
+		if answer == "" || len(c.scopes) == 0 || containsString(c.scopes, answer) {
+			return answer, nil
+		}
+
+		matches := completions(answer, c.scopes)
+		if len(matches) == 1 {
+			fmt.Fprintf(c.out, "completed scope to %q\n", matches[0])
+			return matches[0], nil
+		}
+
+		if suggestions := scope.Suggest(answer, c.scopes); len(suggestions) > 0 {
+			fmt.Fprintf(c.out, "unknown scope %q, did you mean %s?\n", answer, strings.Join(quoteAll(suggestions), " or "))
+		} else if len(matches) > 1 {
+			fmt.Fprintf(c.out, "ambiguous scope %q, one of %s\n", answer, strings.Join(matches, ", "))
+		} else {
+			fmt.Fprintf(c.out, "unknown scope %q, known scopes are %s\n", answer, strings.Join(c.scopes, ", "))
+		}
+	}
+}
+
+// askDescription asks for the description, counting the length of the
+// header while typing
+func (c *composer) askDescription(commitType, scope string) (string, error) {
+	header := func(description string) string {
+		return parser.NewBuilder(commitType, description).Scope(scope).Header()
+	}
+
+	q := question{
+		label: "description",
+		counter: func(input string) string {
+			return fmt.Sprintf(" [%d/%d]", width.String(header(input)), c.headerMax)
+		},
+	}
+	for {
+		answer, err := c.ask(q)
+		if err != nil {
+			return "", err
+		}
+		answer = strings.TrimSpace(answer)
+
+		if answer == "" {
+			fmt.Fprintln(c.out, "description may not be empty")
+			continue
+		}
+		if n := width.String(header(answer)); n > c.headerMax {
+			fmt.Fprintf(c.out, "header is %d characters long, at most %d allowed\n", n, c.headerMax)
+			continue
+		}
+		return answer, nil
+	}
+}
+
+// askBody asks for the lines of an optional body, up to an empty line
+func (c *composer) askBody() (string, error) {
+	fmt.Fprintln(c.out, "body (optional, end with an empty line)")
+
+	var lines []string
+	for {
+		line, err := c.ask(question{label: ">"})
+		if err != nil {
+			return "", err
+		}
+		if strings.TrimSpace(line) == "" {
+			return strings.Join(lines, "\n"), nil
+		}
+		lines = append(lines, strings.TrimRight(line, " \t"))
+	}
+}
+
+// askBreakingChange asks whether the commit is a breaking change, and for
+// its description if it is
+func (c *composer) askBreakingChange() (string, error) {
+	for {
+		answer, err := c.ask(question{label: "breaking change? [y/N]"})
+		if err != nil {
+			return "", err
+		}
+
+		switch strings.ToLower(strings.TrimSpace(answer)) {
+		case "", "n", "no":
+			return "", nil
+		case "y", "yes":
+		default:
+			continue
+		}
+
+		for {
+			description, err := c.ask(question{label: "describe the breaking change"})
+			if err != nil {
+				return "", err
+			}
+			if description = strings.TrimSpace(description); description != "" {
+				return description, nil
+			}
+		}
+	}
+}
+
+// askIssues asks for issue references, separated by spaces or commas, and
+// returns them separated by ", "
+func (c *composer) askIssues() (string, error) {
+	answer, err := c.ask(question{label: "issue references, like #12 #34 (optional)"})
+	if err != nil {
+		return "", err
+	}
+
+	refs := strings.FieldsFunc(answer, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	})
+	return strings.Join(refs, ", "), nil
+}
+
+// configuredScopes returns the scopes of the scope-enum and scope-known
+// rules of the settings, and of the comma separated extra scopes
+func configuredScopes(settings []lint.Setting, extra string) []string {
+	var scopes []string
+	for _, s := range settings {
+		if s.Severity == lint.SeverityOff {
+			continue
+		}
+		switch r := s.Rule.(type) {
+		case *lint.Enum:
+			if r.Part == lint.PartScope && r.When == lint.Always {
+				scopes = append(scopes, r.Values...)
+			}
+		case *lint.KnownScope:
+			scopes = append(scopes, r.Scopes...)
+		}
+	}
+
+	for _, s := range strings.Split(extra, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			scopes = append(scopes, s)
+		}
+	}
+
+	return mergeScopes(scopes)
+}
+
+// mergeScopes returns the sorted, distinct scopes of all lists
+func mergeScopes(lists ...[]string) []string {
+	seen := make(map[string]bool)
+	var scopes []string
+	for _, list := range lists {
+		for _, s := range list {
+			if !seen[s] {
+				seen[s] = true
+				scopes = append(scopes, s)
+			}
+		}
+	}
+	sort.Strings(scopes)
+	return scopes
+}
+
+// headerLimit returns the limit of the header-max-length rule of the settings
+func headerLimit(settings []lint.Setting) int {
+	for _, s := range settings {
+		if r, ok := s.Rule.(*lint.MaxLength); ok && r.Part == lint.PartHeader && s.Severity != lint.SeverityOff {
+			return r.Max
+		}
+	}
+	return defaultHeaderMax
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func quoteAll(values []string) []string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = strconv.Quote(v)
+	}
+	return quoted
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCompose(t *testing.T) {
+	var cases = []struct {
+		args     []string
+		answers  []string
+		expected string
+	}{
+		{
+			nil,
+			[]string{"1", "", "add x", "", "", ""},
+			"feat: add x\n",
+		},
+		{
+			[]string{"-scopes", "api,parser"},
+			[]string{"bugfix", "pars", "handle empty footers", "footers without value", "are skipped now", "", "y", "Note.Value may be empty", "#12, #34"},
+			"fix(parser): handle empty footers\n\nfooters without value are skipped now\n\nBREAKING CHANGE: Note.Value may be empty\nRefs: #12, #34\n",
+		},
+		{
+			// invalid answers are asked again
+			[]string{"-scopes", "api,parser", "-header-max-length", "30"},
+			[]string{"feature-x", "99", "docs", "aip", "api", "", "describe all the endpoints", "describe endpoints", "", "maybe", "n", "#7"},
+			"docs(api): describe endpoints\n\nRefs: #7\n",
+		},
+	}
+
+	for i, tc := range cases {
+		e, stdout, stderr := testEnv(t, strings.Join(tc.answers, "\n")+"\n")
+
+		if code := run(e, append([]string{"compose"}, tc.args...)); code != exitOK {
+			t.Errorf("case#%d: compose = %d, expected %d\n%s", i, code, exitOK, stderr)
+			continue
+		}
+		if stdout.String() != tc.expected {
+			t.Errorf("case#%d: composed\n%q\nexpected\n%q", i, stdout.String(), tc.expected)
+		}
+	}
+}
+
+func TestComposePrompts(t *testing.T) {
+	answers := "feature-x\nfeat\naip\nparser\n\nadd x\n\n\n\n"
+	e, _, stderr := testEnv(t, answers)
+
+	if code := run(e, []string{"compose", "-scopes", "api,parser"}); code != exitOK {
+		t.Fatalf("compose = %d\n%s", code, stderr)
+	}
+
+	for _, expected := range []string{
+		"  1) feat      A new feature\n",
+		"type: feature-x\nunknown type \"feature-x\"",
+		"scope (optional): aip\nunknown scope \"aip\", did you mean \"api\"?\n",
+		"description: \ndescription may not be empty\n",
+		"breaking change? [y/N]: \n",
+	} {
+		if !strings.Contains(stderr.String(), expected) {
+			t.Errorf("prompts do not contain %q:\n%s", expected, stderr)
+		}
+	}
+}
+
+func TestComposeConfig(t *testing.T) {
+	e, stdout, stderr := testEnv(t, "fix\nui\nrepair login\n\n\n\n")
+
+	config := `{"rules": {"scope-enum": [2, "always", ["ui", "server"]], "header-max-length": [2, "always", 24]}}`
+	if err := os.WriteFile(filepath.Join(e.dir, ".commitlintrc.json"), []byte(config), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if code := run(e, []string{"compose", "-file", "MSG"}); code != exitOK {
+		t.Fatalf("compose = %d\n%s", code, stderr)
+	}
+	if stdout.Len() != 0 {
+		t.Errorf("compose -file printed %q", stdout)
+	}
+
+	data, err := os.ReadFile(filepath.Join(e.dir, "MSG"))
+	if err != nil || string(data) != "fix(ui): repair login\n" {
+		t.Errorf("message file %q, %v", data, err)
+	}
+
+	// scopes of the configuration are enforced, the header limit is 24
+	e, _, stderr = testEnv(t, "fix\nweb\nui\nrepair the login form\nrepair login\n\n\n\n")
+	if err := os.WriteFile(filepath.Join(e.dir, ".commitlintrc.json"), []byte(config), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if code := run(e, []string{"compose"}); code != exitOK {
+		t.Fatalf("compose = %d\n%s", code, stderr)
+	}
+	for _, expected := range []string{"unknown scope \"web\", known scopes are server, ui", "header is 30 characters long, at most 24 allowed"} {
+		if !strings.Contains(stderr.String(), expected) {
+			t.Errorf("prompts do not contain %q:\n%s", expected, stderr)
+		}
+	}
+}
+
+func TestComposeErrors(t *testing.T) {
+	// input ends before all questions are answered
+	e, _, stderr := testEnv(t, "feat\n")
+	if code := run(e, []string{"compose"}); code != exitIO {
+		t.Errorf("compose with incomplete answers = %d, expected %d", code, exitIO)
+	}
+	if !strings.Contains(stderr.String(), errIncompleteAnswers.Error()) {
+		t.Errorf("missing error for incomplete answers:\n%s", stderr)
+	}
+
+	// the composed message is validated by parsing it
+	e, _, stderr = testEnv(t, "feat\nbad)scope\nadd x\n\n\n\n")
+	if code := run(e, []string{"compose"}); code != exitInvalid {
+		t.Errorf("compose with invalid scope = %d, expected %d\n%s", code, exitInvalid, stderr)
+	}
+}
+
+func TestCommonPrefix(t *testing.T) {
+	var cases = []struct {
+		values   []string
+		expected string
+	}{
+		{nil, ""},
+		{[]string{"parser"}, "parser"},
+		{[]string{"parser", "parse", "part"}, "par"},
+		{[]string{"über", "übel"}, "übe"},
+		{[]string{"api", "ui"}, ""},
+	}
+
+	for i, tc := range cases {
+		if actual := commonPrefix(tc.values); actual != tc.expected {
+			t.Errorf("case#%d: commonPrefix(%q) = %q, expected %q", i, tc.values, actual, tc.expected)
+		}
+	}
+}
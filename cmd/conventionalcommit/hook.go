@@ -0,0 +1,346 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+
+	"github.com/conventionalcommit/parser"
+	"github.com/conventionalcommit/parser/lint"
+	"github.com/conventionalcommit/parser/report"
+)
+
+// scissors is the line below which git ignores the message in verbose commits
+const scissors = " ------------------------ >8 ------------------------"
+
+var errCleanupMode = "unknown cleanup mode %q, supported modes are strip, whitespace, scissors and verbatim"
+
+// skipPrefixes are the message prefixes of the commits the hook skips, by kind
+var skipPrefixes = map[string][]string{
+	"merge":  {"Merge "},
+	"revert": {"Revert \""},
+	"fixup":  {"fixup! ", "squash! ", "amend! "},
+}
+
+func runHook(e *env, args []string) int {
+	fs := flag.NewFlagSet("hook", flag.ContinueOnError)
+	fs.SetOutput(e.stderr)
+	fs.Usage = func() {
+		fmt.Fprintln(e.stderr, "Usage: conventionalcommit hook [flags] <message-file>")
+		fmt.Fprintln(e.stderr)
+		fmt.Fprintln(e.stderr, "Lints the commit message file git passes to commit-msg hooks.")
+		fmt.Fprintln(e.stderr, "Exits with 1 if the message has errors, and with 3 on I/O errors.")
+		fmt.Fprintln(e.stderr)
+		fs.PrintDefaults()
+	}
+
+	var pf parserFlags
+	pf.register(fs)
+	config := fs.String("config", "", "commitlint configuration `file`, found in the working directory by default")
+	cleanup := fs.String("cleanup", "strip", "git cleanup `mode` applied before linting: strip, whitespace, scissors or verbatim")
+	commentChar := fs.String("comment-char", "#", "`character` starting comment lines, like git's core.commentChar")
+	skip := fs.String("skip", "merge,revert,fixup", "comma separated `kinds` of commits not to lint: merge, revert, fixup")
+	fix := fs.Bool("fix", false, "rewrite the message file with fixes applied")
+
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() != 1 || len(*commentChar) != 1 {
+		fs.Usage()
+		return exitUsage
+	}
+	file := e.path(fs.Arg(0))
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		fmt.Fprintf(e.stderr, "conventionalcommit: %v\n", err)
+		return exitIO
+	}
+
+	msg, err := cleanupMessage(string(data), *cleanup, (*commentChar)[0])
+	if err != nil {
+		fmt.Fprintf(e.stderr, "conventionalcommit: %v\n", err)
+		return exitUsage
+	}
+
+	// git aborts commits with empty messages itself
+	if strings.TrimSpace(msg.text) == "" || isSkipped(msg.text, *skip) {
+		return exitOK
+	}
+
+	settings, err := loadSettings(e, *config)
+	if err != nil {
+		fmt.Fprintf(e.stderr, "conventionalcommit: %v\n", err)
+		var uerr *lint.UnsupportedRulesError
+		if !errors.As(err, &uerr) {
+			return exitIO
+		}
+	}
+	linter := lint.New(pf.parser(), settings...)
+
+	if *fix {
+		fixed, fixes := linter.Fix(msg.text)
+		if len(fixes) > 0 {
+			content := msg.rewrite(fixed)
+			if err := writeFilePreservingMode(file, content); err != nil {
+				fmt.Fprintf(e.stderr, "conventionalcommit: %v\n", err)
+				return exitIO
+			}
+			fmt.Fprintf(e.stderr, "conventionalcommit: fixed %d problems in %s\n", len(fixes), fs.Arg(0))
+			if msg, err = cleanupMessage(content, *cleanup, (*commentChar)[0]); err != nil {
+				fmt.Fprintf(e.stderr, "conventionalcommit: %v\n", err)
+				return exitUsage
+			}
+		}
+	}
+
+	result := msg.original(linter.Lint(msg.text))
+	if len(result.Diagnostics) > 0 {
+		entries := []report.Entry{{Source: fs.Arg(0), Result: result}}
+		if err := pf.pretty(e).Report(e.stderr, entries); err != nil {
+			return exitIO
+		}
+	}
+
+	if !result.Valid() {
+		return exitCode(errInvalidMessage)
+	}
+	return exitOK
+}
+
+// loadSettings returns the settings of the commitlint configuration file, of
+// the configuration found in the working directory, or the conventional preset
+func loadSettings(e *env, config string) ([]lint.Setting, error) {
+	path := config
+	if path == "" {
+		found, err := lint.FindConfig(e.dir)
+		if err != nil {
+			return lint.Conventional(), nil
+		}
+		path = found
+	}
+
+	c, err := lint.LoadConfig(e.path(path))
+	if err != nil {
+		return nil, err
+	}
+	return c.Settings()
+}
+
+// cleanedMessage is a commit message file cleaned up the way git commit
+// --cleanup does, with the positions of its lines in the file
+type cleanedMessage struct {
+	// text is the cleaned up message
+	text string
+	// file is the content of the message file
+	file string
+	// lines are the indexes of the lines of the file that the lines of text
+	// come from
+	lines []int
+}
+
+// cleanupMessage cleans up a commit message the way git commit --cleanup does
+func cleanupMessage(msg, mode string, commentChar byte) (*cleanedMessage, error) {
+	var text string
+	var lines []int
+
+	switch mode {
+	case "verbatim":
+		text = msg
+		for i := range strings.Split(msg, "\n") {
+			lines = append(lines, i)
+		}
+	case "whitespace":
+		text, lines = stripSpace(msg, 0)
+	case "scissors":
+		text, lines = stripSpace(cutScissors(msg, commentChar), 0)
+	case "strip", "default":
+		text, lines = stripSpace(cutScissors(msg, commentChar), commentChar)
+	default:
+		return nil, fmt.Errorf(errCleanupMode, mode)
+	}
+
+	return &cleanedMessage{text: text, file: msg, lines: lines}, nil
+}
+
+// cutScissors removes the scissors line and everything below it
+func cutScissors(msg string, commentChar byte) string {
+	line := string(commentChar) + scissors + "\n"
+	if strings.HasPrefix(msg, line) {
+		return ""
+	}
+	if i := strings.Index(msg, "\n"+line); i >= 0 {
+		return msg[:i+1]
+	}
+	return msg
+}
+
+// stripSpace removes trailing whitespace, leading and trailing blank lines,
+// and collapses consecutive blank lines, like git stripspace. Lines starting
+// with commentChar are removed unless it is 0. It returns the stripped
+// message and the indexes of the lines of msg its lines come from.
+func stripSpace(msg string, commentChar byte) (string, []int) {
+	var lines []string
+	var from []int
+	blank := -1
+
+	for i, line := range strings.Split(msg, "\n") {
+		if commentChar != 0 && strings.HasPrefix(line, string(commentChar)) {
+			continue
+		}
+
+		line = strings.TrimRight(line, " \t\r\v\f")
+		if line == "" {
+			if blank < 0 && len(lines) > 0 {
+				blank = i
+			}
+			continue
+		}
+
+		if blank >= 0 {
+			lines = append(lines, "")
+			from = append(from, blank)
+			blank = -1
+		}
+		lines = append(lines, line)
+		from = append(from, i)
+	}
+
+	return strings.Join(lines, "\n"), from
+}
+
+// position returns the offset in the file of an offset in the text
+func (m *cleanedMessage) position(offset int) int {
+	line := strings.Count(m.text[:offset], "\n")
+	column := offset - strings.LastIndex(m.text[:offset], "\n") - 1
+
+	start := 0
+	for i := 0; i < m.lines[line]; i++ {
+		start += strings.IndexByte(m.file[start:], '\n') + 1
+	}
+	return start + column
+}
+
+// original returns the lint result of the text with its message and spans
+// referring to the file, so that positions match the lines of the file
+func (m *cleanedMessage) original(r *lint.Result) *lint.Result {
+	// spans of results are relative to the trimmed text
+	lead := len(m.text) - len(strings.TrimLeftFunc(m.text, unicode.IsSpace))
+	span := func(s parser.Span) parser.Span {
+		return parser.Span{Start: m.position(lead + s.Start), End: m.position(lead + s.End)}
+	}
+
+	mapped := &lint.Result{Message: m.file, Commit: r.Commit}
+	for _, d := range r.Diagnostics {
+		d.Span = span(d.Span)
+		if d.Fix != nil {
+			fix := &lint.Fix{Rule: d.Fix.Rule}
+			for _, edit := range d.Fix.Edits {
+				fix.Edits = append(fix.Edits, parser.Edit{Span: span(edit.Span), Text: edit.Text})
+			}
+			d.Fix = fix
+		}
+		mapped.Diagnostics = append(mapped.Diagnostics, d)
+	}
+	return mapped
+}
+
+// rewrite returns the file with the lines of the text replaced by the lines
+// of fixed. Lines removed by the cleanup, like comments and the scissors
+// section, are kept, and lines inserted by fixes follow the line before
+// them, so that they stay above the comments below it.
+func (m *cleanedMessage) rewrite(fixed string) string {
+	old := strings.Split(m.text, "\n")
+	kept, inserted := diffLines(old, strings.Split(fixed, "\n"))
+
+	// fileLine maps the indexes of file lines to the text lines from them
+	fileLine := make(map[int]int, len(m.lines))
+	for i, l := range m.lines {
+		fileLine[l] = i
+	}
+
+	var out []string
+	out = append(out, inserted[0]...)
+	for l, line := range strings.Split(m.file, "\n") {
+		i, ok := fileLine[l]
+		if !ok {
+			out = append(out, line)
+			continue
+		}
+		if kept[i] {
+			out = append(out, line)
+		}
+		out = append(out, inserted[i+1]...)
+	}
+
+	content := strings.Join(out, "\n")
+	if !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+	return content
+}
+
+// diffLines compares the lines of a and b by their longest common
+// subsequence. It returns whether each line of a is kept, and the lines of
+// b inserted before each line of a, with the lines inserted at the end at
+// index len(a).
+func diffLines(a, b []string) ([]bool, [][]string) {
+	// common[i][j] is the length of the common subsequence of a[i:] and b[j:]
+	common := make([][]int, len(a)+1)
+	for i := range common {
+		common[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			switch {
+			case a[i] == b[j]:
+				common[i][j] = common[i+1][j+1] + 1
+			case common[i+1][j] >= common[i][j+1]:
+				common[i][j] = common[i+1][j]
+			default:
+				common[i][j] = common[i][j+1]
+			}
+		}
+	}
+
+	kept := make([]bool, len(a))
+	inserted := make([][]string, len(a)+1)
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			kept[i] = true
+			i++
+			j++
+		case j == len(b) || (i < len(a) && common[i+1][j] >= common[i][j+1]):
+			i++
+		default:
+			inserted[i] = append(inserted[i], b[j])
+			j++
+		}
+	}
+	return kept, inserted
+}
+
+// isSkipped reports whether msg is of one of the comma separated kinds
+func isSkipped(msg, kinds string) bool {
+	for _, kind := range strings.Split(kinds, ",") {
+		for _, prefix := range skipPrefixes[strings.TrimSpace(kind)] {
+			if strings.HasPrefix(msg, prefix) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func writeFilePreservingMode(name, content string) error {
+	mode := os.FileMode(0o644)
+	if info, err := os.Stat(name); err == nil {
+		mode = info.Mode().Perm()
+	}
+	return os.WriteFile(name, []byte(content), mode)
+}
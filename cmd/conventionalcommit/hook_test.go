@@ -0,0 +1,163 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCleanupMessage(t *testing.T) {
+	msg := "\n\nfeat: add x  \n\n\n# Please enter the commit message\nbody\t\n\n#comment\n\n# ------------------------ >8 ------------------------\ndiff --git a/x b/x\n"
+
+	var cases = map[string]string{
+		"strip":      "feat: add x\n\nbody",
+		"whitespace": "feat: add x\n\n# Please enter the commit message\nbody\n\n#comment\n\n# ------------------------ >8 ------------------------\ndiff --git a/x b/x",
+		"scissors":   "feat: add x\n\n# Please enter the commit message\nbody\n\n#comment",
+		"verbatim":   msg,
+	}
+
+	for mode, expected := range cases {
+		actual, err := cleanupMessage(msg, mode, '#')
+		if err != nil {
+			t.Errorf("%s: %v", mode, err)
+			continue
+		}
+		if actual.text != expected {
+			t.Errorf("cleanupMessage(%s) = %q, expected %q", mode, actual.text, expected)
+		}
+	}
+
+	if actual, _ := cleanupMessage("fix: y\n;comment\n# kept", "strip", ';'); actual.text != "fix: y\n# kept" {
+		t.Errorf("cleanupMessage with comment char ';' = %q", actual.text)
+	}
+	if _, err := cleanupMessage(msg, "none", '#'); err == nil {
+		t.Error("cleanupMessage(none) should fail")
+	}
+}
+
+func writeMessage(t *testing.T, dir, msg string) string {
+	t.Helper()
+
+	path := filepath.Join(dir, "COMMIT_EDITMSG")
+	if err := os.WriteFile(path, []byte(msg), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestHook(t *testing.T) {
+	var cases = []struct {
+		msg      string
+		args     []string
+		expected int
+	}{
+		{"feat: add x\n# comment\n", nil, exitOK},
+		{"feat: add x.\n", nil, exitInvalid},
+		{"Feat: add x\n", nil, exitInvalid},
+		{"feat: Add x\n# Please enter", []string{"-cleanup", "verbatim"}, exitInvalid},
+		{"Merge branch 'main' into feature\n", nil, exitOK},
+		{"Revert \"feat: add x\"\n\nThis reverts commit abc.\n", nil, exitOK},
+		{"fixup! feat: add x\n", nil, exitOK},
+		{"fixup! feat: add x\n", []string{"-skip", "merge"}, exitInvalid},
+		{"# only comments\n\n", nil, exitOK},
+		{"feat:add x\n", nil, exitInvalid},
+	}
+
+	for _, tc := range cases {
+		e, _, stderr := testEnv(t, "")
+		path := writeMessage(t, e.dir, tc.msg)
+
+		args := append(append([]string{"hook"}, tc.args...), path)
+		if code := run(e, args); code != tc.expected {
+			t.Errorf("hook %q %v = %d, expected %d\n%s", tc.msg, tc.args, code, tc.expected, stderr)
+		}
+	}
+}
+
+func TestHookFix(t *testing.T) {
+	e, _, stderr := testEnv(t, "")
+	path := writeMessage(t, e.dir, "Feat:add x.\nbody\n# Please enter the commit message\n")
+
+	if code := run(e, []string{"hook", "-fix", "COMMIT_EDITMSG"}); code != exitOK {
+		t.Fatalf("hook -fix = %d\n%s", code, stderr)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "feat: add x\n\nbody\n# Please enter the commit message\n" {
+		t.Errorf("fixed message = %q", data)
+	}
+	if info, _ := os.Stat(path); info.Mode().Perm() != 0o600 {
+		t.Errorf("file mode changed to %v", info.Mode())
+	}
+	if !strings.Contains(stderr.String(), "fixed") {
+		t.Errorf("no fix reported:\n%s", stderr)
+	}
+}
+
+func TestHookFixKeepsComments(t *testing.T) {
+	msg := "Feat: Add x\n# Please enter the commit message for your changes.\n#\n\nBody\n# ------------------------ >8 ------------------------\n# Do not modify or remove the line above.\ndiff --git a/x b/x\n"
+	expected := "feat: add x\n# Please enter the commit message for your changes.\n#\n\nBody\n# ------------------------ >8 ------------------------\n# Do not modify or remove the line above.\ndiff --git a/x b/x\n"
+
+	e, _, stderr := testEnv(t, "")
+	path := writeMessage(t, e.dir, msg)
+
+	if code := run(e, []string{"hook", "-fix", "COMMIT_EDITMSG"}); code != exitOK {
+		t.Fatalf("hook -fix = %d\n%s", code, stderr)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != expected {
+		t.Errorf("fixed message = %q, expected %q", data, expected)
+	}
+}
+
+func TestHookPositions(t *testing.T) {
+	e, _, stderr := testEnv(t, "")
+	writeMessage(t, e.dir, "# Please enter the commit message\n#\nfeat: add x.\n")
+
+	if code := run(e, []string{"hook", "COMMIT_EDITMSG"}); code != exitInvalid {
+		t.Fatalf("hook = %d, expected %d\n%s", code, exitInvalid, stderr)
+	}
+	if !strings.Contains(stderr.String(), "COMMIT_EDITMSG:3:12") {
+		t.Errorf("position does not refer to the file:\n%s", stderr)
+	}
+}
+
+func TestHookConfig(t *testing.T) {
+	e, _, stderr := testEnv(t, "")
+	config := `{"rules": {"type-enum": [2, "always", ["feat"]], "scope-empty": [1, "never"]}}`
+	if err := os.WriteFile(filepath.Join(e.dir, ".commitlintrc.json"), []byte(config), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	path := writeMessage(t, e.dir, "feat: add x\n")
+	if code := run(e, []string{"hook", path}); code != exitOK {
+		t.Errorf("hook = %d, expected warnings only\n%s", code, stderr)
+	}
+	if !strings.Contains(stderr.String(), "warning[scope-empty]") {
+		t.Errorf("missing warning:\n%s", stderr)
+	}
+
+	path = writeMessage(t, e.dir, "fix: add x\n")
+	if code := run(e, []string{"hook", path}); code != exitInvalid {
+		t.Errorf("hook = %d, expected type-enum error", code)
+	}
+}
+
+func TestHookIOError(t *testing.T) {
+	e, _, _ := testEnv(t, "")
+
+	if code := run(e, []string{"hook", "missing"}); code != exitIO {
+		t.Errorf("hook missing = %d, expected %d", code, exitIO)
+	}
+	if code := run(e, []string{"hook"}); code != exitUsage {
+		t.Errorf("hook = %d, expected %d", code, exitUsage)
+	}
+}
@@ -0,0 +1,222 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// hookName is the git hook the tool is installed as
+	hookName = "commit-msg"
+	// hookMarker identifies hooks installed by the tool
+	hookMarker = "# installed by conventionalcommit"
+	// chainedSuffix is appended to the name of an existing hook, which the
+	// installed hook runs first
+	chainedSuffix = ".pre-conventionalcommit"
+)
+
+var (
+	errHookNotInstalled = errors.New("commit-msg hook was not installed by conventionalcommit")
+	errChainedExists    = "%s already exists, remove it or the existing commit-msg hook"
+	errGit              = "git %s: %v: %s"
+)
+
+// hookScript is the commit-msg hook, it runs a chained hook before the tool
+const hookScript = `#!/bin/sh
+` + hookMarker + `
+hook="$(dirname "$0")/` + hookName + chainedSuffix + `"
+if [ -x "$hook" ]; then
+	"$hook" "$@" || exit $?
+fi
+exec %s hook%s "$@"
+`
+
+func runInstallHook(e *env, args []string) int {
+	fs := flag.NewFlagSet("install-hook", flag.ContinueOnError)
+	fs.SetOutput(e.stderr)
+	fs.Usage = func() {
+		fmt.Fprintln(e.stderr, "Usage: conventionalcommit install-hook [flags]")
+		fmt.Fprintln(e.stderr)
+		fmt.Fprintln(e.stderr, "Installs the commit-msg hook of the repository in the working directory.")
+		fmt.Fprintln(e.stderr, "An existing hook is kept and runs before the installed one.")
+		fmt.Fprintln(e.stderr)
+		fs.PrintDefaults()
+	}
+	command := fs.String("command", "conventionalcommit", "`command` the hook runs")
+	fix := fs.Bool("fix", false, "rewrite commit messages with fixes applied")
+
+	if err := fs.Parse(args); err != nil || fs.NArg() > 0 {
+		return exitUsage
+	}
+
+	dir, err := hooksDir(e.dir)
+	if err != nil {
+		fmt.Fprintf(e.stderr, "conventionalcommit: %v\n", err)
+		return exitIO
+	}
+
+	path, err := installHook(dir, *command, *fix)
+	if err != nil {
+		fmt.Fprintf(e.stderr, "conventionalcommit: %v\n", err)
+		return exitIO
+	}
+
+	fmt.Fprintf(e.stdout, "installed %s\n", path)
+	return exitOK
+}
+
+func runUninstallHook(e *env, args []string) int {
+	fs := flag.NewFlagSet("uninstall-hook", flag.ContinueOnError)
+	fs.SetOutput(e.stderr)
+	fs.Usage = func() {
+		fmt.Fprintln(e.stderr, "Usage: conventionalcommit uninstall-hook")
+		fmt.Fprintln(e.stderr)
+		fmt.Fprintln(e.stderr, "Removes the installed commit-msg hook and restores a chained hook.")
+	}
+
+	if err := fs.Parse(args); err != nil || fs.NArg() > 0 {
+		return exitUsage
+	}
+
+	dir, err := hooksDir(e.dir)
+	if err != nil {
+		fmt.Fprintf(e.stderr, "conventionalcommit: %v\n", err)
+		return exitIO
+	}
+
+	path, err := uninstallHook(dir)
+	if err != nil {
+		fmt.Fprintf(e.stderr, "conventionalcommit: %v\n", err)
+		return exitIO
+	}
+
+	fmt.Fprintf(e.stdout, "uninstalled %s\n", path)
+	return exitOK
+}
+
+// installHook writes the commit-msg hook into dir. An existing hook not
+// installed by the tool is renamed to be chained. Installing again updates
+// the hook.
+func installHook(dir, command string, fix bool) (string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(dir, hookName)
+	chained := path + chainedSuffix
+
+	installed, err := isInstalledHook(path)
+	if err != nil {
+		return "", err
+	}
+
+	if !installed && fileExists(path) {
+		if fileExists(chained) {
+			return "", fmt.Errorf(errChainedExists, chained)
+		}
+		if err := os.Rename(path, chained); err != nil {
+			return "", err
+		}
+	}
+
+	flags := ""
+	if fix {
+		flags = " -fix"
+	}
+	script := fmt.Sprintf(hookScript, shellQuote(command), flags)
+
+	if err := os.WriteFile(path, []byte(script), 0o755); err != nil {
+		return "", err
+	}
+	// WriteFile keeps the mode of existing files
+	return path, os.Chmod(path, 0o755)
+}
+
+// uninstallHook removes the commit-msg hook installed by the tool and
+// restores the chained hook
+func uninstallHook(dir string) (string, error) {
+	path := filepath.Join(dir, hookName)
+
+	installed, err := isInstalledHook(path)
+	if err != nil {
+		return "", err
+	}
+	if !installed {
+		return "", errHookNotInstalled
+	}
+
+	if err := os.Remove(path); err != nil {
+		return "", err
+	}
+
+	chained := path + chainedSuffix
+	if fileExists(chained) {
+		return path, os.Rename(chained, path)
+	}
+	return path, nil
+}
+
+func isInstalledHook(path string) (bool, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return bytes.Contains(data, []byte(hookMarker)), nil
+}
+
+// hooksDir returns the hooks directory of the repository at dir. git
+// resolves core.hooksPath, the common directory of worktrees and the git
+// directory of submodules. Relative paths are relative to the top-level
+// directory of the working tree.
+func hooksDir(dir string) (string, error) {
+	top, err := git(dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", err
+	}
+
+	hooks, err := git(top, "rev-parse", "--git-path", "hooks")
+	if err != nil {
+		return "", err
+	}
+
+	if !filepath.IsAbs(hooks) {
+		hooks = filepath.Join(top, hooks)
+	}
+	return filepath.Clean(hooks), nil
+}
+
+// git runs git in dir and returns its trimmed output
+func git(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf(errGit, strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+func fileExists(name string) bool {
+	_, err := os.Lstat(name)
+	return err == nil
+}
+
+// shellQuote quotes s for sh if it contains special characters
+func shellQuote(s string) string {
+	if s != "" && strings.Trim(s, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_./") == "" {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// mainEnv makes the test binary run main, so installed hooks can run it
+const mainEnv = "CONVENTIONALCOMMIT_TEST_MAIN"
+
+func TestMain(m *testing.M) {
+	if os.Getenv(mainEnv) == "1" {
+		main()
+	}
+	os.Exit(m.Run())
+}
+
+// gitRepo creates a repository with an initial commit in a temporary directory
+func gitRepo(t *testing.T) string {
+	t.Helper()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found")
+	}
+
+	dir := t.TempDir()
+	runGit(t, dir, "init", "-q", "-b", "main")
+	runGit(t, dir, "commit", "-q", "--allow-empty", "-m", "chore: init")
+	return dir
+}
+
+func runGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+
+	out, err := gitCommand(dir, args...).CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func gitCommand(dir string, args ...string) *exec.Cmd {
+	args = append([]string{
+		"-c", "user.name=Test", "-c", "user.email=test@example.com",
+		"-c", "protocol.file.allow=always", "-c", "init.defaultBranch=main",
+	}, args...)
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), mainEnv+"=1", "GIT_CONFIG_NOSYSTEM=1", "HOME="+dir)
+	return cmd
+}
+
+func installIn(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+
+	e, stdout, stderr := testEnv(t, "")
+	e.dir = dir
+
+	args = append([]string{"install-hook", "-command", os.Args[0]}, args...)
+	if code := run(e, args); code != exitOK {
+		t.Fatalf("install-hook = %d\n%s", code, stderr)
+	}
+	return strings.TrimPrefix(strings.TrimSpace(stdout.String()), "installed ")
+}
+
+func TestInstallHookCommits(t *testing.T) {
+	dir := gitRepo(t)
+	path := installIn(t, dir)
+
+	if expected := filepath.Join(dir, ".git", "hooks", hookName); path != expected {
+		t.Errorf("installed %s, expected %s", path, expected)
+	}
+
+	if out, err := gitCommand(dir, "commit", "--allow-empty", "-m", "added x.").CombinedOutput(); err == nil {
+		t.Errorf("commit with invalid message succeeded\n%s", out)
+	} else if !strings.Contains(string(out), "error[") {
+		t.Errorf("commit failed without diagnostics\n%s", out)
+	}
+
+	runGit(t, dir, "commit", "-q", "--allow-empty", "-m", "feat: add x")
+	runGit(t, dir, "commit", "-q", "--allow-empty", "-m", "fixup! feat: add x")
+}
+
+func TestInstallHookFix(t *testing.T) {
+	dir := gitRepo(t)
+	installIn(t, dir, "-fix")
+
+	runGit(t, dir, "commit", "-q", "--allow-empty", "-m", "Feat:add x.")
+
+	if msg := runGit(t, dir, "log", "-1", "--format=%B"); msg != "feat: add x" {
+		t.Errorf("committed message %q, expected fixed message", msg)
+	}
+}
+
+func TestInstallHookChains(t *testing.T) {
+	dir := gitRepo(t)
+	hooks := filepath.Join(dir, ".git", "hooks")
+
+	existing := "#!/bin/sh\necho chained >> \"" + filepath.Join(dir, "chained.log") + "\"\n"
+	if err := os.WriteFile(filepath.Join(hooks, hookName), []byte(existing), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	installIn(t, dir)
+	installIn(t, dir) // installing again keeps the chained hook
+
+	runGit(t, dir, "commit", "-q", "--allow-empty", "-m", "feat: add x")
+
+	if data, err := os.ReadFile(filepath.Join(dir, "chained.log")); err != nil || string(data) != "chained\n" {
+		t.Errorf("chained hook did not run once: %q, %v", data, err)
+	}
+
+	e, _, stderr := testEnv(t, "")
+	e.dir = dir
+	if code := run(e, []string{"uninstall-hook"}); code != exitOK {
+		t.Fatalf("uninstall-hook = %d\n%s", code, stderr)
+	}
+
+	data, err := os.ReadFile(filepath.Join(hooks, hookName))
+	if err != nil || string(data) != existing {
+		t.Errorf("existing hook not restored: %q, %v", data, err)
+	}
+	if code := run(e, []string{"uninstall-hook"}); code != exitIO {
+		t.Errorf("uninstall-hook of foreign hook = %d, expected %d", code, exitIO)
+	}
+}
+
+func TestInstallHookLocations(t *testing.T) {
+	t.Run("hooksPath", func(t *testing.T) {
+		dir := gitRepo(t)
+		runGit(t, dir, "config", "core.hooksPath", "githooks")
+
+		sub := filepath.Join(dir, "sub")
+		if err := os.Mkdir(sub, 0o755); err != nil {
+			t.Fatal(err)
+		}
+
+		if path := installIn(t, sub); path != filepath.Join(dir, "githooks", hookName) {
+			t.Errorf("installed %s, expected core.hooksPath", path)
+		}
+	})
+
+	t.Run("worktree", func(t *testing.T) {
+		dir := gitRepo(t)
+		worktree := filepath.Join(t.TempDir(), "wt")
+		runGit(t, dir, "worktree", "add", "-q", worktree)
+
+		if path := installIn(t, worktree); path != filepath.Join(dir, ".git", "hooks", hookName) {
+			t.Errorf("installed %s, expected hooks of the main repository", path)
+		}
+	})
+
+	t.Run("submodule", func(t *testing.T) {
+		lib := gitRepo(t)
+		dir := gitRepo(t)
+		runGit(t, dir, "submodule", "add", "-q", lib, "lib")
+
+		path := installIn(t, filepath.Join(dir, "lib"))
+		if expected := filepath.Join(dir, ".git", "modules", "lib", "hooks", hookName); path != expected {
+			t.Errorf("installed %s, expected %s", path, expected)
+		}
+	})
+}
+
+func TestShellQuote(t *testing.T) {
+	var cases = map[string]string{
+		"conventionalcommit":  "conventionalcommit",
+		"/usr/local/bin/cc":   "/usr/local/bin/cc",
+		"/path with space/cc": "'/path with space/cc'",
+		"/it's/cc":            `'/it'\''s/cc'`,
+	}
+
+	for s, expected := range cases {
+		if actual := shellQuote(s); actual != expected {
+			t.Errorf("shellQuote(%q) = %q, expected %q", s, actual, expected)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+// Command conventionalcommit parses and lints conventional commit messages
+//
+// Usage:
+//
+//	conventionalcommit <command> [flags] [arguments]
+//
+// Commands:
+//
+//	compose          compose a commit message interactively
+//	parse            parse a commit message and print its parts
+//	hook             lint the message file of a git commit-msg hook
+//	install-hook     install the commit-msg hook in the current repository
+//	uninstall-hook   remove the installed commit-msg hook
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// Exit codes of the commands
+const (
+	// exitOK is returned if the command succeeds
+	exitOK = 0
+	// exitInvalid is returned if a commit message is invalid
+	exitInvalid = 1
+	// exitUsage is returned for unknown commands and invalid flags
+	exitUsage = 2
+	// exitIO is returned if reading input or writing output fails
+	exitIO = 3
+)
+
+// errInvalidMessage marks errors of invalid commit messages
+var errInvalidMessage = errors.New("invalid commit message")
+
+// env is the environment of a command, replaced in tests
+type env struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+	getenv func(string) string
+	dir    string
+}
+
+// path returns name relative to the working directory of the environment
+func (e *env) path(name string) string {
+	if filepath.IsAbs(name) {
+		return name
+	}
+	return filepath.Join(e.dir, name)
+}
+
+// command is a subcommand of the tool
+type command struct {
+	summary string
+	run     func(e *env, args []string) int
+}
+
+// commands are the subcommands by name
+var commands = map[string]command{
+	"compose":        {"compose a commit message interactively", runCompose},
+	"parse":          {"parse a commit message and print its parts", runParse},
+	"hook":           {"lint the message file of a git commit-msg hook", runHook},
+	"install-hook":   {"install the commit-msg hook in the current repository", runInstallHook},
+	"uninstall-hook": {"remove the installed commit-msg hook", runUninstallHook},
+}
+
+func main() {
+	dir, _ := os.Getwd()
+	e := &env{
+		stdin:  os.Stdin,
+		stdout: os.Stdout,
+		stderr: os.Stderr,
+		getenv: os.Getenv,
+		dir:    dir,
+	}
+	os.Exit(run(e, os.Args[1:]))
+}
+
+// run runs the command of args and returns the exit code
+func run(e *env, args []string) int {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "-help" || args[0] == "--help" || args[0] == "help" {
+		usage(e.stderr)
+		return exitUsage
+	}
+
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(e.stderr, "conventionalcommit: unknown command %q\n\n", args[0])
+		usage(e.stderr)
+		return exitUsage
+	}
+
+	return cmd.run(e, args[1:])
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: conventionalcommit <command> [flags] [arguments]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-14s %s\n", name, commands[name].summary)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, `Run "conventionalcommit <command> -h" for the flags of a command.`)
+}
+
+// exitCode returns the exit code of an error of a command
+func exitCode(err error) int {
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.Is(err, errInvalidMessage):
+		return exitInvalid
+	default:
+		return exitIO
+	}
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// testEnv returns an environment reading stdin, in a temporary directory
+func testEnv(t *testing.T, stdin string) (*env, *bytes.Buffer, *bytes.Buffer) {
+	t.Helper()
+
+	var stdout, stderr bytes.Buffer
+	e := &env{
+		stdin:  strings.NewReader(stdin),
+		stdout: &stdout,
+		stderr: &stderr,
+		getenv: func(string) string { return "" },
+		dir:    t.TempDir(),
+	}
+	return e, &stdout, &stderr
+}
+
+func TestRunUsage(t *testing.T) {
+	e, _, stderr := testEnv(t, "")
+
+	if code := run(e, nil); code != exitUsage {
+		t.Errorf("run() = %d, expected %d", code, exitUsage)
+	}
+	if code := run(e, []string{"frobnicate"}); code != exitUsage {
+		t.Errorf("run(frobnicate) = %d, expected %d", code, exitUsage)
+	}
+	if !strings.Contains(stderr.String(), "parse") {
+		t.Errorf("usage does not list commands:\n%s", stderr)
+	}
+}
+
+func TestParseFormats(t *testing.T) {
+	msg := "feat(api)!: add x\n\nbody\n\nRefs: #1"
+
+	var cases = []struct {
+		args     []string
+		expected string
+	}{
+		{[]string{"-format", "table"}, "type         feat\nscope        api\ndescription  add x\nbreaking     true\nbody         body\nRefs         #1\n"},
+		{[]string{"-template", "{{.Type}} {{.Scope}} {{range .Notes}}{{.Token}}={{.Value}}{{end}}"}, "feat api Refs=#1\n"},
+		{[]string{"-format", "yaml"}, "type: feat\ncanonicalType: feat\nscope: api\ndescription: add x\nbreaking: true\nheader: 'feat(api)!: add x'\nbody: body\nfooter: 'Refs: #1'\nnotes:\n  - token: Refs\n    value: '#1'\n    breaking: false\n"},
+	}
+
+	for _, tc := range cases {
+		e, stdout, stderr := testEnv(t, msg)
+
+		if code := run(e, append([]string{"parse"}, tc.args...)); code != exitOK {
+			t.Errorf("parse %v = %d, expected %d: %s", tc.args, code, exitOK, stderr)
+			continue
+		}
+		if stdout.String() != tc.expected {
+			t.Errorf("parse %v =\n%q\nexpected\n%q", tc.args, stdout.String(), tc.expected)
+		}
+	}
+}
+
+func TestParseJSON(t *testing.T) {
+	e, stdout, _ := testEnv(t, "")
+
+	if code := run(e, []string{"parse", "fix: y\n\nBREAKING CHANGE: z"}); code != exitOK {
+		t.Fatalf("parse = %d", code)
+	}
+
+	var v commitView
+	if err := json.Unmarshal(stdout.Bytes(), &v); err != nil {
+		t.Fatal(err)
+	}
+	if v.Type != "fix" || !v.Breaking || len(v.Notes) != 1 || v.Notes[0].Value != "z" {
+		t.Errorf("unexpected commit %+v", v)
+	}
+}
+
+func TestParseFile(t *testing.T) {
+	e, stdout, _ := testEnv(t, "")
+
+	if err := os.WriteFile(filepath.Join(e.dir, "MSG"), []byte("docs: readme\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if code := run(e, []string{"parse", "-file", "MSG", "-template", "{{.Description}}"}); code != exitOK {
+		t.Fatalf("parse = %d", code)
+	}
+	if stdout.String() != "readme\n" {
+		t.Errorf("parse = %q", stdout.String())
+	}
+}
+
+func TestParseExitCodes(t *testing.T) {
+	var cases = []struct {
+		args     []string
+		stdin    string
+		expected int
+	}{
+		{[]string{"parse"}, "feat: x", exitOK},
+		{[]string{"parse", "feat x"}, "", exitInvalid},
+		{[]string{"parse", "-strict", "feat:  x"}, "", exitInvalid},
+		{[]string{"parse", "-file", "missing"}, "", exitIO},
+		{[]string{"parse", "-format", "xml", "feat: x"}, "", exitUsage},
+		{[]string{"parse", "-template", "{{.Type", "feat: x"}, "", exitUsage},
+		{[]string{"parse", "-format", "template", "feat: x"}, "", exitUsage},
+		{[]string{"parse", "-format", "yaml", "-template", "{{.Type}}", "feat: x"}, "", exitUsage},
+		{[]string{"parse", "-format", "template", "-template", "{{.Type}}", "feat: x"}, "", exitOK},
+		{[]string{"parse", "-unknown"}, "", exitUsage},
+		{[]string{"parse", "feat: x", "feat: y"}, "", exitUsage},
+	}
+
+	for _, tc := range cases {
+		e, _, stderr := testEnv(t, tc.stdin)
+		if code := run(e, tc.args); code != tc.expected {
+			t.Errorf("%v = %d, expected %d: %s", tc.args, code, tc.expected, stderr)
+		}
+	}
+}
+
+func TestParseDiagnostics(t *testing.T) {
+	e, _, stderr := testEnv(t, "feat: x\nbody")
+
+	run(e, []string{"parse"})
+
+	expected := "error[parse]: at least one empty line required after header\n --> <stdin>:2:1\n"
+	if !strings.HasPrefix(stderr.String(), expected) {
+		t.Errorf("diagnostics =\n%s", stderr)
+	}
+}
+
+func TestTerminalWidth(t *testing.T) {
+	e, _, _ := testEnv(t, "")
+	if w := terminalWidth(e); w != 0 {
+		t.Errorf("terminalWidth() = %d without terminal, expected 0", w)
+	}
+
+	e.getenv = func(name string) string {
+		if name == "COLUMNS" {
+			return "120"
+		}
+		return ""
+	}
+	if w := terminalWidth(e); w != 120 {
+		t.Errorf("terminalWidth() = %d, expected $COLUMNS", w)
+	}
+
+	var cases = map[string]int{
+		"24 80":  80,
+		"50 132": 132,
+		"":       0,
+		"size":   0,
+	}
+	for size, expected := range cases {
+		if actual := sizeColumns(size); actual != expected {
+			t.Errorf("sizeColumns(%q) = %d, expected %d", size, actual, expected)
+		}
+	}
+}
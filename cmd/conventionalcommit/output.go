@@ -0,0 +1,150 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/conventionalcommit/parser"
+)
+
+var (
+	errTemplateEmpty = errors.New("format template requires -template")
+
+	errUnknownFormat  = "unknown format %q, supported formats are json, yaml, table and template"
+	errTemplateFormat = "-template cannot be used with format %q"
+)
+
+// commitView is the output form of a commit, also the data of templates
+type commitView struct {
+	Type          string     `json:"type" yaml:"type"`
+	CanonicalType string     `json:"canonicalType" yaml:"canonicalType"`
+	Scope         string     `json:"scope" yaml:"scope"`
+	Description   string     `json:"description" yaml:"description"`
+	Breaking      bool       `json:"breaking" yaml:"breaking"`
+	Header        string     `json:"header" yaml:"header"`
+	Body          string     `json:"body" yaml:"body"`
+	Footer        string     `json:"footer" yaml:"footer"`
+	Notes         []noteView `json:"notes" yaml:"notes"`
+}
+
+type noteView struct {
+	Token    string `json:"token" yaml:"token"`
+	Value    string `json:"value" yaml:"value"`
+	Breaking bool   `json:"breaking" yaml:"breaking"`
+}
+
+func newCommitView(c *parser.Commit) commitView {
+	v := commitView{
+		Type:          c.Type(),
+		CanonicalType: c.CanonicalType(),
+		Scope:         c.Scope(),
+		Description:   c.Description(),
+		Breaking:      c.IsBreakingChange(),
+		Header:        c.Header(),
+		Body:          c.Body(),
+		Footer:        c.Footer(),
+		Notes:         []noteView{},
+	}
+
+	for _, n := range c.Notes() {
+		v.Notes = append(v.Notes, noteView{
+			Token:    n.Token(),
+			Value:    n.Value(),
+			Breaking: n.IsBreakingChange(),
+		})
+	}
+
+	return v
+}
+
+// output writes a commit
+type output func(w io.Writer, v commitView) error
+
+// newOutput returns the output of a format
+func newOutput(format, tmpl string) (output, error) {
+	switch format {
+	case "json":
+		return outputJSON, nil
+	case "yaml":
+		return outputYAML, nil
+	case "table":
+		return outputTable, nil
+	case "template":
+		if tmpl == "" {
+			return nil, errTemplateEmpty
+		}
+		t, err := template.New("commit").Parse(tmpl)
+		if err != nil {
+			return nil, err
+		}
+		return func(w io.Writer, v commitView) error {
+			if err := t.Execute(w, v); err != nil {
+				return err
+			}
+			_, err := io.WriteString(w, "\n")
+			return err
+		}, nil
+	default:
+		return nil, fmt.Errorf(errUnknownFormat, format)
+	}
+}
+
+// outputFormat returns the format of the -format and -template flags. A
+// template implies the template format, and cannot be combined with another
+// format given explicitly.
+func outputFormat(format, tmpl string, formatSet bool) (string, error) {
+	if tmpl == "" {
+		return format, nil
+	}
+	if formatSet && format != "template" {
+		return "", fmt.Errorf(errTemplateFormat, format)
+	}
+	return "template", nil
+}
+
+func outputJSON(w io.Writer, v commitView) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func outputYAML(w io.Writer, v commitView) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// outputTable writes a row for every part, multi-line parts continue on rows without name
+func outputTable(w io.Writer, v commitView) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+	row := func(name, value string) {
+		for i, line := range strings.Split(value, "\n") {
+			if i > 0 {
+				name = ""
+			}
+			fmt.Fprintf(tw, "%s\t%s\n", name, line)
+		}
+	}
+
+	row("type", v.Type)
+	row("scope", v.Scope)
+	row("description", v.Description)
+	row("breaking", fmt.Sprint(v.Breaking))
+	row("body", v.Body)
+	for _, n := range v.Notes {
+		row(n.Token, n.Value)
+	}
+
+	return tw.Flush()
+}
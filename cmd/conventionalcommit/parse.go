@@ -0,0 +1,178 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/conventionalcommit/parser"
+	"github.com/conventionalcommit/parser/lint"
+	"github.com/conventionalcommit/parser/report"
+)
+
+// parserFlags configure the parser and diagnostics of commands parsing messages
+type parserFlags struct {
+	strict      bool
+	gitTrailers bool
+	color       string
+}
+
+func (f *parserFlags) register(fs *flag.FlagSet) {
+	fs.BoolVar(&f.strict, "strict", false, "parse strictly by the Conventional Commits 1.0.0 specification")
+	fs.BoolVar(&f.gitTrailers, "git-trailers", false, "detect footers the way git interpret-trailers does")
+	fs.StringVar(&f.color, "color", "auto", "color diagnostics: auto, always or never")
+}
+
+// parser returns the parser configured by the flags and opts
+func (f *parserFlags) parser(opts ...parser.Option) *parser.Parser {
+	if f.strict {
+		opts = append(opts, parser.WithMode(parser.ModeStrictV100))
+	}
+	if f.gitTrailers {
+		opts = append(opts, parser.WithGitTrailers(""))
+	}
+	return parser.New(opts...)
+}
+
+// messageSource returns the name of the message source used in diagnostics
+func messageSource(args []string, file string) string {
+	switch {
+	case len(args) > 0:
+		return "<argument>"
+	case file != "" && file != "-":
+		return file
+	default:
+		return "<stdin>"
+	}
+}
+
+// readMessage returns the first argument, or the content of the file or stdin
+func readMessage(e *env, args []string, file string) (string, error) {
+	switch {
+	case len(args) > 0:
+		return args[0], nil
+	case file != "" && file != "-":
+		data, err := os.ReadFile(e.path(file))
+		return string(data), err
+	default:
+		data, err := io.ReadAll(e.stdin)
+		return string(data), err
+	}
+}
+
+// pretty returns the diagnostic renderer for stderr
+func (f *parserFlags) pretty(e *env) report.Pretty {
+	color := false
+	switch f.color {
+	case "always":
+		color = true
+	case "auto":
+		color = isTerminal(e.stderr) && e.getenv("NO_COLOR") == ""
+	}
+	return report.Pretty{Color: color, Width: terminalWidth(e)}
+}
+
+func runParse(e *env, args []string) int {
+	fs := flag.NewFlagSet("parse", flag.ContinueOnError)
+	fs.SetOutput(e.stderr)
+	fs.Usage = func() {
+		fmt.Fprintln(e.stderr, "Usage: conventionalcommit parse [flags] [message]")
+		fmt.Fprintln(e.stderr)
+		fmt.Fprintln(e.stderr, "Parses the message argument, the -file or stdin and prints the commit.")
+		fmt.Fprintln(e.stderr, "Exits with 1 if the message is invalid, and with 3 on I/O errors.")
+		fmt.Fprintln(e.stderr)
+		fs.PrintDefaults()
+	}
+
+	var pf parserFlags
+	pf.register(fs)
+	file := fs.String("file", "", "read the message from `path`, - for stdin")
+	format := fs.String("format", "json", "output `format`: json, yaml, table or template")
+	tmpl := fs.String("template", "", "format the commit with a Go `template`, like '{{.Type}}: {{.Description}}', implies -format template")
+
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() > 1 {
+		fs.Usage()
+		return exitUsage
+	}
+
+	formatSet := false
+	fs.Visit(func(f *flag.Flag) {
+		formatSet = formatSet || f.Name == "format"
+	})
+	outFormat, err := outputFormat(*format, *tmpl, formatSet)
+	if err != nil {
+		fmt.Fprintf(e.stderr, "conventionalcommit: %v\n", err)
+		return exitUsage
+	}
+
+	out, err := newOutput(outFormat, *tmpl)
+	if err != nil {
+		fmt.Fprintf(e.stderr, "conventionalcommit: %v\n", err)
+		return exitUsage
+	}
+
+	msg, err := readMessage(e, fs.Args(), *file)
+	if err != nil {
+		fmt.Fprintf(e.stderr, "conventionalcommit: %v\n", err)
+		return exitIO
+	}
+
+	result := lint.New(pf.parser()).Lint(msg)
+	if result.Commit == nil {
+		entries := []report.Entry{{Source: messageSource(fs.Args(), *file), Result: result}}
+		if err := pf.pretty(e).Report(e.stderr, entries); err != nil {
+			return exitIO
+		}
+		return exitCode(errInvalidMessage)
+	}
+
+	if err := out(e.stdout, newCommitView(result.Commit)); err != nil {
+		fmt.Fprintf(e.stderr, "conventionalcommit: %v\n", err)
+		return exitIO
+	}
+	return exitOK
+}
+
+// isTerminal reports whether w is a character device, like a terminal
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// terminalWidth returns the width of the terminal from $COLUMNS, or as
+// reported by stty size if stderr is a terminal, or 0 if unknown
+func terminalWidth(e *env) int {
+	var width int
+	if _, err := fmt.Sscanf(strings.TrimSpace(e.getenv("COLUMNS")), "%d", &width); err == nil {
+		return width
+	}
+
+	f, ok := e.stderr.(*os.File)
+	if !ok || !isTerminal(f) {
+		return 0
+	}
+	size, err := stty(f, "size")
+	if err != nil {
+		return 0
+	}
+	return sizeColumns(size)
+}
+
+// sizeColumns returns the columns of the output of stty size, like "24 80",
+// or 0 if it cannot be parsed
+func sizeColumns(size string) int {
+	var rows, columns int
+	if _, err := fmt.Sscanf(size, "%d %d", &rows, &columns); err != nil {
+		return 0
+	}
+	return columns
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"unicode"
+)
+
+// Control characters read from the terminal in raw mode
+const (
+	keyInterrupt = 3
+	keyEOF       = 4
+	keyBackspace = 8
+	keyTab       = '\t'
+	keyKillLine  = 21
+	keyEscape    = 27
+	keyDelete    = 127
+)
+
+var errInterrupted = errors.New("interrupted")
+
+// question is a prompt for a single line of input
+type question struct {
+	label string
+	// counter returns the text shown after the label while typing, like
+	// a length counter. It is only shown on terminals.
+	counter func(input string) string
+	// complete returns the candidates completing the input
+	complete func(input string) []string
+}
+
+// prompter asks questions and returns the answers
+type prompter interface {
+	ask(q question) (string, error)
+}
+
+// newPrompter returns a line editor if stdin is a terminal that stty can
+// switch to raw mode, and reads answers line by line from stdin otherwise
+func newPrompter(e *env) prompter {
+	if f, ok := e.stdin.(*os.File); ok && isTerminal(f) {
+		if _, err := stty(f, "-g"); err == nil {
+			return &ttyPrompter{in: f, r: bufio.NewReader(f), out: e.stderr}
+		}
+	}
+	return &scriptPrompter{r: bufio.NewReader(e.stdin), out: e.stderr}
+}
+
+// scriptPrompter reads one answer per line, to script the composer
+type scriptPrompter struct {
+	r   *bufio.Reader
+	out io.Writer
+}
+
+func (p *scriptPrompter) ask(q question) (string, error) {
+	fmt.Fprintf(p.out, "%s: ", q.label)
+
+	line, err := p.r.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		fmt.Fprintln(p.out)
+		return "", err
+	}
+
+	line = strings.TrimRight(line, "\r\n")
+	fmt.Fprintln(p.out, line)
+	return line, nil
+}
+
+// ttyPrompter edits answers on a terminal in raw mode, with live counters
+// and completion on tab
+type ttyPrompter struct {
+	in  *os.File
+	r   *bufio.Reader
+	out io.Writer
+}
+
+func (p *ttyPrompter) ask(q question) (string, error) {
+	restore, err := rawMode(p.in)
+	if err != nil {
+		return "", err
+	}
+	defer restore()
+
+	var input []rune
+	p.draw(q, input)
+
+	for {
+		c, _, err := p.r.ReadRune()
+		if err != nil {
+			return "", err
+		}
+
+		switch c {
+		case '\r', '\n':
+			fmt.Fprintln(p.out)
+			return string(input), nil
+		case keyInterrupt:
+			fmt.Fprintln(p.out)
+			return "", errInterrupted
+		case keyEOF:
+			if len(input) == 0 {
+				fmt.Fprintln(p.out)
+				return "", io.EOF
+			}
+		case keyBackspace, keyDelete:
+			if len(input) > 0 {
+				input = input[:len(input)-1]
+			}
+		case keyKillLine:
+			input = nil
+		case keyTab:
+			input = p.complete(q, input)
+		case keyEscape:
+			p.skipEscape()
+		default:
+			if unicode.IsPrint(c) {
+				input = append(input, c)
+			}
+		}
+
+		p.draw(q, input)
+	}
+}
+
+// draw redraws the line of the question with the current input
+func (p *ttyPrompter) draw(q question, input []rune) {
+	counter := ""
+	if q.counter != nil {
+		counter = q.counter(string(input))
+	}
+	fmt.Fprintf(p.out, "\r\033[K%s%s: %s", q.label, counter, string(input))
+}
+
+// complete extends the input to the common prefix of its completions, and
+// lists them if that does not change the input
+func (p *ttyPrompter) complete(q question, input []rune) []rune {
+	if q.complete == nil {
+		return input
+	}
+
+	candidates := q.complete(string(input))
+	if len(candidates) == 0 {
+		return input
+	}
+
+	prefix := commonPrefix(candidates)
+	if len(candidates) > 1 && prefix == string(input) {
+		fmt.Fprintf(p.out, "\n%s\n", strings.Join(candidates, "  "))
+	}
+	return []rune(prefix)
+}
+
+// skipEscape consumes the rest of an escape sequence, like of a cursor key
+func (p *ttyPrompter) skipEscape() {
+	c, _, err := p.r.ReadRune()
+	if err != nil || (c != '[' && c != 'O') {
+		return
+	}
+	for {
+		c, _, err := p.r.ReadRune()
+		if err != nil || (c >= '@' && c <= '~') {
+			return
+		}
+	}
+}
+
+// rawMode disables line buffering, echo and signals of the terminal f and
+// returns a function restoring its previous state
+func rawMode(f *os.File) (func(), error) {
+	state, err := stty(f, "-g")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := stty(f, "-icanon", "-echo", "-isig", "min", "1"); err != nil {
+		return nil, err
+	}
+	return func() { _, _ = stty(f, state) }, nil
+}
+
+// stty runs stty with args on the terminal f
+func stty(f *os.File, args ...string) (string, error) {
+	cmd := exec.Command("stty", args...)
+	cmd.Stdin = f
+	out, err := cmd.Output()
+	return strings.TrimSpace(string(out)), err
+}
+
+// completions returns the candidates starting with input
+func completions(input string, candidates []string) []string {
+	var matches []string
+	for _, c := range candidates {
+		if strings.HasPrefix(c, input) {
+			matches = append(matches, c)
+		}
+	}
+	return matches
+}
+
+// commonPrefix returns the longest common prefix of the strings
+func commonPrefix(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+
+	prefix := []rune(values[0])
+	for _, v := range values[1:] {
+		r := []rune(v)
+		n := 0
+		for n < len(prefix) && n < len(r) && prefix[n] == r[n] {
+			n++
+		}
+		prefix = prefix[:n]
+	}
+	return string(prefix)
+}
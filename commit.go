@@ -1,5 +1,12 @@
 package parser
 
+import (
+	"strings"
+	"unicode"
+
+	"github.com/conventionalcommit/parser/wrap"
+)
+
 // Commit represents a commit that adheres to the conventional commits specification
 type Commit struct {
 	message string
@@ -8,12 +15,36 @@ type Commit struct {
 	body   string
 	footer string
 
-	commitType  string
-	scope       string
-	description string
-	notes       []Note
+	commitType    string
+	canonicalType string
+	scope         string
+	description   string
+	notes         []Note
 
 	isBreakingChange bool
+
+	headerSpan      Span
+	bodySpan        Span
+	footerSpan      Span
+	typeSpan        Span
+	scopeSpan       Span
+	descriptionSpan Span
+
+	// footerSeparators and gitTrailerSeparators are the options the commit
+	// was parsed with
+	footerSeparators     []string
+	gitTrailerSeparators string
+}
+
+// WrapOptions returns the options that make the wrap package recognize
+// footer notes with the separators the commit was parsed with, to reflow
+// its body and footer
+func (c *Commit) WrapOptions() []wrap.Option {
+	opts := []wrap.Option{wrap.WithFooterSeparators(c.footerSeparators...)}
+	if c.gitTrailerSeparators != "" {
+		opts = append(opts, wrap.WithGitTrailers(c.gitTrailerSeparators))
+	}
+	return opts
 }
 
 // Message returns input commit message
@@ -41,6 +72,13 @@ func (c *Commit) Type() string {
 	return c.commitType
 }
 
+// CanonicalType returns the registered name of the commit type, resolving
+// aliases. If the parser has no TypeRegistry or the type is unknown, it is
+// the same as Type.
+func (c *Commit) CanonicalType() string {
+	return c.canonicalType
+}
+
 // Scope returns scope of the commit
 func (c *Commit) Scope() string {
 	return c.scope
@@ -61,10 +99,65 @@ func (c *Commit) IsBreakingChange() bool {
 	return c.isBreakingChange
 }
 
+// HeaderSpan returns the position of the header in the message
+func (c *Commit) HeaderSpan() Span {
+	return c.headerSpan
+}
+
+// BodySpan returns the position of the body in the message
+func (c *Commit) BodySpan() Span {
+	return c.bodySpan
+}
+
+// FooterSpan returns the position of the footer in the message
+func (c *Commit) FooterSpan() Span {
+	return c.footerSpan
+}
+
+// TypeSpan returns the position of the type in the message
+func (c *Commit) TypeSpan() Span {
+	return c.typeSpan
+}
+
+// ScopeSpan returns the position of the scope in the message
+func (c *Commit) ScopeSpan() Span {
+	return c.scopeSpan
+}
+
+// DescriptionSpan returns the position of the description in the message
+func (c *Commit) DescriptionSpan() Span {
+	return c.descriptionSpan
+}
+
+// Span is the position of a part of the commit message, as byte offsets
+// into Message. End is exclusive. Missing parts have an empty Span.
+type Span struct {
+	Start, End int
+}
+
+// Len returns the length of the span in bytes
+func (s Span) Len() int {
+	return s.End - s.Start
+}
+
+// trimmedSpan returns the span of src[start:end] without leading and trailing whitespace
+func trimmedSpan(src string, start, end int) Span {
+	text := src[start:end]
+	trimmed := strings.TrimLeftFunc(text, unicode.IsSpace)
+	start += len(text) - len(trimmed)
+	end = start + len(strings.TrimRightFunc(trimmed, unicode.IsSpace))
+	return Span{Start: start, End: end}
+}
+
 // Note represents one footer note
 type Note struct {
-	token string
-	value string
+	token     string
+	separator string
+	value     string
+
+	isBreaking bool
+
+	span Span
 }
 
 func newNote(token, value string) Note {
@@ -79,7 +172,27 @@ func (n *Note) Token() string {
 	return n.token
 }
 
+// Separator returns the separator between token and value of the Footer Note, like ": " or " #"
+func (n *Note) Separator() string {
+	return n.separator
+}
+
 // Value returns the value of the Footer Note
 func (n *Note) Value() string {
 	return n.value
 }
+
+// IsBreakingChange returns true if the token of the Footer Note is a breaking change token
+func (n *Note) IsBreakingChange() bool {
+	return n.isBreaking
+}
+
+// Span returns the position of the Footer Note in the message
+func (n *Note) Span() Span {
+	return n.span
+}
+
+// String returns the Footer Note as it appears in a commit message
+func (n *Note) String() string {
+	return n.token + n.separator + n.value
+}
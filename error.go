@@ -0,0 +1,43 @@
+package parser
+
+// Error is returned by Parse for messages that cannot be parsed
+type Error struct {
+	// Err is the cause of the error
+	Err error
+	// Span is the position of the error in the message, after leading and
+	// trailing whitespace is removed
+	Span Span
+	// Edits fix the error, empty if it cannot be fixed automatically
+	Edits []Edit
+}
+
+// Error returns the message of the cause
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the cause of the error
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Edit replaces the text at Span with Text. An empty Span inserts Text.
+type Edit struct {
+	Span Span
+	Text string
+}
+
+func newError(err error, span Span, edits ...Edit) *Error {
+	return &Error{
+		Err:   err,
+		Span:  span,
+		Edits: edits,
+	}
+}
+
+func insertEdit(pos int, text string) Edit {
+	return Edit{
+		Span: Span{Start: pos, End: pos},
+		Text: text,
+	}
+}
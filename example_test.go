@@ -24,5 +24,22 @@ By: John Doe`
 	}
 	fmt.Printf("%#v", commit)
 
-	// Output: &parser.Commit{message:"feat(scope): description\n\nthis is first line in body\n\nthis is second line in body\n\nRef #123\nDate: 01-01-2021\nBy: John Doe", header:"feat(scope): description", body:"this is first line in body\n\nthis is second line in body", footer:"Ref #123\nDate: 01-01-2021\nBy: John Doe", commitType:"feat", scope:"scope", description:"description", notes:[]parser.Note{parser.Note{token:"Ref", value:"123"}, parser.Note{token:"Date", value:"01-01-2021"}, parser.Note{token:"By", value:"John Doe"}}, isBreakingChange:false}
+	// Output: &parser.Commit{message:"feat(scope): description\n\nthis is first line in body\n\nthis is second line in body\n\nRef #123\nDate: 01-01-2021\nBy: John Doe", header:"feat(scope): description", body:"this is first line in body\n\nthis is second line in body", footer:"Ref #123\nDate: 01-01-2021\nBy: John Doe", commitType:"feat", canonicalType:"feat", scope:"scope", description:"description", notes:[]parser.Note{parser.Note{token:"Ref", separator:" #", value:"123", isBreaking:false, span:parser.Span{Start:83, End:91}}, parser.Note{token:"Date", separator:": ", value:"01-01-2021", isBreaking:false, span:parser.Span{Start:92, End:108}}, parser.Note{token:"By", separator:": ", value:"John Doe", isBreaking:false, span:parser.Span{Start:109, End:121}}}, isBreakingChange:false, headerSpan:parser.Span{Start:0, End:24}, bodySpan:parser.Span{Start:26, End:81}, footerSpan:parser.Span{Start:83, End:121}, typeSpan:parser.Span{Start:0, End:4}, scopeSpan:parser.Span{Start:5, End:10}, descriptionSpan:parser.Span{Start:13, End:24}, footerSeparators:[]string{": ", " #"}, gitTrailerSeparators:""}
+}
+
+func ExampleBuilder() {
+	b := parser.NewBuilder("feat", "add config reload").
+		Scope("server").
+		Body("the server reloads its configuration on SIGHUP without dropping open connections").
+		Footer("Refs", "#42").
+		Wrap(40)
+
+	fmt.Println(b)
+
+	// Output: feat(server): add config reload
+	//
+	// the server reloads its configuration on
+	// SIGHUP without dropping open connections
+	//
+	// Refs: #42
 }
@@ -0,0 +1,192 @@
+// Package forge generates links to commits, issues, pull requests, compares
+// and users of repositories hosted on GitHub, GitLab, Gitea or Bitbucket
+package forge
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+var (
+	errRemoteInvalid = "invalid remote URL %q"
+	errHostUnknown   = "cannot detect the forge of %q, use one of %s"
+	errPresetUnknown = "unknown forge %q, supported forges are %s"
+)
+
+// Host are the URL templates of a forge. Templates contain placeholders
+// replaced when links are generated:
+//
+//	{root}  the URL of the host, like https://github.com
+//	{base}  the URL of the repository, like https://github.com/owner/repo
+//	{hash}  a commit hash
+//	{id}    an issue or pull request number
+//	{from}  the older tag of a compare
+//	{to}    the newer tag of a compare
+//	{tag}   a tag
+//	{user}  a user name
+type Host struct {
+	// Name is the name of the preset, like "github"
+	Name string
+	// Commit links a commit
+	Commit string
+	// Issue links an issue
+	Issue string
+	// PullRequest links a pull request or merge request
+	PullRequest string
+	// Compare links the changes between two tags
+	Compare string
+	// Tag links a tag or release
+	Tag string
+	// User links a user profile
+	User string
+	// PullRequestPrefix starts references to pull requests, like "!" on
+	// GitLab. If empty, "#" references issues and pull requests alike.
+	PullRequestPrefix string
+}
+
+// Presets of the supported forges
+var (
+	GitHub = Host{
+		Name:        "github",
+		Commit:      "{base}/commit/{hash}",
+		Issue:       "{base}/issues/{id}",
+		PullRequest: "{base}/pull/{id}",
+		Compare:     "{base}/compare/{from}...{to}",
+		Tag:         "{base}/releases/tag/{tag}",
+		User:        "{root}/{user}",
+	}
+	GitLab = Host{
+		Name:              "gitlab",
+		Commit:            "{base}/-/commit/{hash}",
+		Issue:             "{base}/-/issues/{id}",
+		PullRequest:       "{base}/-/merge_requests/{id}",
+		Compare:           "{base}/-/compare/{from}...{to}",
+		Tag:               "{base}/-/tags/{tag}",
+		User:              "{root}/{user}",
+		PullRequestPrefix: "!",
+	}
+	Gitea = Host{
+		Name:        "gitea",
+		Commit:      "{base}/commit/{hash}",
+		Issue:       "{base}/issues/{id}",
+		PullRequest: "{base}/pulls/{id}",
+		Compare:     "{base}/compare/{from}...{to}",
+		Tag:         "{base}/releases/tag/{tag}",
+		User:        "{root}/{user}",
+	}
+	Bitbucket = Host{
+		Name:        "bitbucket",
+		Commit:      "{base}/commits/{hash}",
+		Issue:       "{base}/issues/{id}",
+		PullRequest: "{base}/pull-requests/{id}",
+		Compare:     "{base}/branches/compare/{to}%0D{from}",
+		Tag:         "{base}/src/{tag}",
+		User:        "{root}/{user}/",
+	}
+)
+
+// presets are the presets by name
+var presets = map[string]Host{
+	GitHub.Name:    GitHub,
+	GitLab.Name:    GitLab,
+	Gitea.Name:     Gitea,
+	Bitbucket.Name: Bitbucket,
+}
+
+// hostPatterns detect presets by a part of the host name of remotes
+var hostPatterns = []struct {
+	pattern string
+	host    Host
+}{
+	{"github", GitHub},
+	{"gitlab", GitLab},
+	{"bitbucket", Bitbucket},
+	{"gitea", Gitea},
+	{"forgejo", Gitea},
+	{"codeberg.org", Gitea},
+}
+
+// Preset returns the preset of a forge by name, like "gitlab"
+func Preset(name string) (Host, error) {
+	h, ok := presets[strings.ToLower(name)]
+	if !ok {
+		return Host{}, fmt.Errorf(errPresetUnknown, name, strings.Join(Presets(), ", "))
+	}
+	return h, nil
+}
+
+// Presets returns the names of all presets, sorted
+func Presets() []string {
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Detect returns the preset of the forge hosting a remote URL, like
+// "git@github.com:owner/repo.git", from its host name
+func Detect(remote string) (Host, error) {
+	r, err := parseRemote(remote)
+	if err != nil {
+		return Host{}, err
+	}
+
+	hostname := strings.ToLower(r.hostname)
+	for _, p := range hostPatterns {
+		if strings.Contains(hostname, p.pattern) {
+			return p.host, nil
+		}
+	}
+	return Host{}, fmt.Errorf(errHostUnknown, r.hostname, strings.Join(Presets(), ", "))
+}
+
+// remote is the web location of a repository of a remote URL
+type remote struct {
+	hostname string
+	// root is the web URL of the host, like https://gitlab.com
+	root string
+	// path is the repository path, like "group/subgroup/repo"
+	path string
+}
+
+// parseRemote parses remote URLs of git, like https://host/owner/repo.git,
+// ssh://git@host:22/owner/repo.git or git@host:owner/repo.git
+func parseRemote(s string) (remote, error) {
+	s = strings.TrimSpace(s)
+
+	var r remote
+	if strings.Contains(s, "://") {
+		u, err := url.Parse(s)
+		if err != nil || u.Hostname() == "" {
+			return remote{}, fmt.Errorf(errRemoteInvalid, s)
+		}
+
+		r.hostname = u.Hostname()
+		r.root = "https://" + u.Hostname()
+		if u.Scheme == "http" || u.Scheme == "https" {
+			r.root = u.Scheme + "://" + u.Host
+		}
+		r.path = u.Path
+	} else {
+		// scp-like syntax [user@]host:path
+		at := strings.Index(s, "@") + 1
+		colon := strings.Index(s[at:], ":")
+		if colon <= 0 {
+			return remote{}, fmt.Errorf(errRemoteInvalid, s)
+		}
+
+		r.hostname = s[at : at+colon]
+		r.root = "https://" + r.hostname
+		r.path = s[at+colon+1:]
+	}
+
+	r.path = strings.TrimSuffix(strings.Trim(r.path, "/"), ".git")
+	if !strings.Contains(r.path, "/") {
+		return remote{}, fmt.Errorf(errRemoteInvalid, s)
+	}
+	return r, nil
+}
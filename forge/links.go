@@ -0,0 +1,215 @@
+package forge
+
+import (
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/conventionalcommit/parser"
+)
+
+// ReferenceKind is the kind of a reference in a commit message
+type ReferenceKind int
+
+const (
+	// ReferenceIssue references an issue, or a pull request on forges that
+	// do not distinguish them, like "#12"
+	ReferenceIssue ReferenceKind = iota
+	// ReferencePullRequest references a pull request, like "!12" on GitLab
+	ReferencePullRequest
+	// ReferenceCommit references a commit by its hash
+	ReferenceCommit
+	// ReferenceUser mentions a user, like "@octocat"
+	ReferenceUser
+)
+
+// References start at the beginning of a line or after whitespace or
+// punctuation, so that parts of words, paths, URLs and e-mail addresses are
+// not mistaken for references
+var (
+	// issueReference matches "#12", "!12" and "owner/repo#12"
+	issueReference = regexp.MustCompile(`(^|[\s(\[{,;:"'<])((?:[\w.-]+/[\w.-]+)?)([#!])(\d+)\b`)
+	// commitReference matches abbreviated or full commit hashes
+	commitReference = regexp.MustCompile(`(^|[\s(\[{,;:"'<])([0-9a-f]{7,40})\b`)
+	// userReference matches mentions, like "@octocat"
+	userReference = regexp.MustCompile(`(^|[\s(\[{,;:"'<])@([A-Za-z0-9](?:[A-Za-z0-9-]*[A-Za-z0-9])?)\b`)
+)
+
+// Reference is an issue, pull request, commit or user referenced in a
+// commit message
+type Reference struct {
+	Kind ReferenceKind
+	// Text is the reference as written, like "#12" or "owner/repo#12"
+	Text string
+	// Repository is the repository path of cross-repository references,
+	// like "owner/repo", empty for the linked repository
+	Repository string
+	// ID is the issue or pull request number, commit hash or user name
+	ID string
+	// URL links the reference
+	URL string
+	// Start and End are the byte offsets of the reference in the text
+	Start, End int
+}
+
+// Links generates links into a repository
+type Links struct {
+	host Host
+	root string
+	path string
+}
+
+// FromRemote returns the links of the repository of a remote URL, with the
+// preset of its forge detected from the host name
+func FromRemote(remoteURL string) (*Links, error) {
+	host, err := Detect(remoteURL)
+	if err != nil {
+		return nil, err
+	}
+	return New(host, remoteURL)
+}
+
+// New returns the links of the repository of a remote URL, using the URL
+// templates of host. It is used for forges on hosts that cannot be
+// detected, like self-hosted GitLab instances.
+func New(host Host, remoteURL string) (*Links, error) {
+	r, err := parseRemote(remoteURL)
+	if err != nil {
+		return nil, err
+	}
+	return &Links{host: host, root: r.root, path: r.path}, nil
+}
+
+// Host returns the URL templates of the links
+func (l *Links) Host() Host {
+	return l.host
+}
+
+// Repository returns the web URL of the repository
+func (l *Links) Repository() string {
+	return l.root + "/" + l.path
+}
+
+// Commit returns the URL of a commit
+func (l *Links) Commit(hash string) string {
+	return l.expand(l.host.Commit, l.path, "{hash}", hash)
+}
+
+// Issue returns the URL of an issue
+func (l *Links) Issue(id string) string {
+	return l.expand(l.host.Issue, l.path, "{id}", id)
+}
+
+// PullRequest returns the URL of a pull request
+func (l *Links) PullRequest(id string) string {
+	return l.expand(l.host.PullRequest, l.path, "{id}", id)
+}
+
+// Compare returns the URL of the changes between two tags or commits
+func (l *Links) Compare(from, to string) string {
+	return l.expand(l.host.Compare, l.path, "{from}", url.PathEscape(from), "{to}", url.PathEscape(to))
+}
+
+// Tag returns the URL of a tag
+func (l *Links) Tag(tag string) string {
+	return l.expand(l.host.Tag, l.path, "{tag}", url.PathEscape(tag))
+}
+
+// User returns the URL of the profile of a user
+func (l *Links) User(name string) string {
+	return l.expand(l.host.User, l.path, "{user}", url.PathEscape(name))
+}
+
+// expand replaces the placeholders of a URL template for the repository at path
+func (l *Links) expand(template, path string, pairs ...string) string {
+	if template == "" {
+		return ""
+	}
+	pairs = append(pairs, "{root}", l.root, "{base}", l.root+"/"+path)
+	return strings.NewReplacer(pairs...).Replace(template)
+}
+
+// References returns the issue, pull request, commit and user references
+// in the description, body and footers of a commit, in the order of the
+// message
+func (l *Links) References(c *parser.Commit) []Reference {
+	return l.Find(c.Message())
+}
+
+// Find returns the references in text, in the order of the text
+func (l *Links) Find(text string) []Reference {
+	var refs []Reference
+
+	for _, m := range issueReference.FindAllStringSubmatchIndex(text, -1) {
+		repo, prefix, id := text[m[4]:m[5]], text[m[6]:m[7]], text[m[8]:m[9]]
+
+		path := l.path
+		if repo != "" {
+			path = repo
+		}
+
+		ref := Reference{Text: text[m[4]:m[1]], Repository: repo, ID: id, Start: m[4], End: m[1]}
+		switch {
+		case l.host.PullRequestPrefix != "" && prefix == l.host.PullRequestPrefix:
+			ref.Kind = ReferencePullRequest
+			ref.URL = l.expand(l.host.PullRequest, path, "{id}", id)
+		case prefix == "#":
+			ref.Kind = ReferenceIssue
+			ref.URL = l.expand(l.host.Issue, path, "{id}", id)
+		default:
+			continue
+		}
+		refs = append(refs, ref)
+	}
+
+	for _, m := range commitReference.FindAllStringSubmatchIndex(text, -1) {
+		hash := text[m[4]:m[5]]
+		if !isHash(hash) {
+			continue
+		}
+		refs = append(refs, Reference{Kind: ReferenceCommit, Text: hash, ID: hash, URL: l.Commit(hash), Start: m[4], End: m[5]})
+	}
+
+	for _, m := range userReference.FindAllStringSubmatchIndex(text, -1) {
+		name := text[m[4]:m[5]]
+		refs = append(refs, Reference{Kind: ReferenceUser, Text: "@" + name, ID: name, URL: l.User(name), Start: m[4] - 1, End: m[5]})
+	}
+
+	sort.Slice(refs, func(i, j int) bool {
+		return refs[i].Start < refs[j].Start
+	})
+	return refs
+}
+
+// Linkify replaces the references in text with Markdown links, like
+// "[#12](https://github.com/owner/repo/issues/12)". Commit hashes are
+// shortened to 7 characters.
+func (l *Links) Linkify(text string) string {
+	var b strings.Builder
+	last := 0
+
+	for _, ref := range l.Find(text) {
+		if ref.URL == "" || ref.Start < last {
+			continue
+		}
+
+		label := ref.Text
+		if ref.Kind == ReferenceCommit && len(label) > 7 {
+			label = label[:7]
+		}
+
+		b.WriteString(text[last:ref.Start])
+		b.WriteString("[" + label + "](" + ref.URL + ")")
+		last = ref.End
+	}
+
+	b.WriteString(text[last:])
+	return b.String()
+}
+
+// isHash reports whether s looks like a commit hash rather than a number
+// or a word, containing both digits and letters
+func isHash(s string) bool {
+	return strings.ContainsAny(s, "0123456789") && strings.ContainsAny(s, "abcdef")
+}
@@ -1,3 +1,5 @@
 module github.com/conventionalcommit/parser
 
 go 1.17
+
+require gopkg.in/yaml.v3 v3.0.1
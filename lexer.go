@@ -29,6 +29,10 @@ type lexer struct {
 	startState stateFunc
 	tokenCh    chan token
 
+	breakingTokens       []string
+	footerSeparators     []string
+	gitTrailerSeparators string
+
 	err          error
 	errorHandler func(err error)
 }
@@ -70,14 +74,19 @@ func (l *lexer) NextToken() (*token, bool) {
 }
 
 // Error if an errorHandler is given, sets lex.Err with given error and calls errorHandler
-// if no errorHandler is given, then it panics with given error.
-func (l *lexer) Error(e error) {
+// if no errorHandler is given, then it panics with given error. The error is
+// positioned at the next rune, edits can be given to fix it.
+func (l *lexer) Error(e error, edits ...Edit) {
+	_, size := utf8.DecodeRuneInString(l.source[l.currentPos:])
+	span := Span{Start: l.currentPos, End: l.currentPos + size}
+
+	err := newError(e, span, edits...)
 	if l.errorHandler == nil {
-		panic(e)
+		panic(err)
 	}
 
-	l.err = e
-	l.errorHandler(e)
+	l.err = err
+	l.errorHandler(err)
 }
 
 // Current returns the value being being analyzed at this moment.
@@ -139,6 +148,13 @@ func (l *lexer) Rewind() {
 	}
 }
 
+// Seek moves the current position to pos. The rewind stack is cleared, so
+// pos must not be before the last point a token was emitted.
+func (l *lexer) Seek(pos int) {
+	l.clearRune()
+	l.currentPos = pos
+}
+
 // Next pulls the next rune from the Lexer and returns it, moving the position
 // forward in the source.
 func (l *lexer) Next() rune {
@@ -3,12 +3,17 @@ package parser
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"unicode"
+	"unicode/utf8"
 )
 
 const (
 	breakingTokenSpace  = "BREAKING CHANGE"
 	breakingTokenHyphen = "BREAKING-CHANGE"
+
+	footerSeparatorColon = ": "
+	footerSeparatorHash  = " #"
 )
 
 var (
@@ -41,6 +46,7 @@ const (
 
 	footerDelimterToken
 	footerKeyToken
+	footerBreakingKeyToken
 	footerValueToken
 )
 
@@ -113,6 +119,11 @@ func descriptionDelimiterState(l *lexer) stateFunc {
 
 	l.Next()
 
+	if l.Current() == ":" && l.Peek() != ' ' && l.Peek() != eof {
+		l.Error(errDescMissingDelimiter, insertEdit(l.currentPos, " "))
+		return nil
+	}
+
 	if l.Current() != ":" || l.Peek() != ' ' {
 		l.Error(errDescMissingDelimiter)
 		return nil
@@ -146,7 +157,7 @@ func headerDelimeterState(l *lexer) stateFunc {
 	l.Take("\n")
 
 	if len(l.Current()) < 2 {
-		l.Error(errHeaderMissingEmptyLine)
+		l.Error(errHeaderMissingEmptyLine, insertEdit(l.currentPos, "\n"))
 		return nil
 	}
 
@@ -156,11 +167,12 @@ func headerDelimeterState(l *lexer) stateFunc {
 }
 
 func bodyOrFooterState(l *lexer) stateFunc {
-	count, isFooter := checkIfFooterToken(l)
+	if l.gitTrailerSeparators != "" {
+		return gitTrailerState
+	}
 
 	// there is no body
-	if isFooter {
-		rewind(l, count)
+	if _, _, found := footerAt(l, l.currentPos); found {
 		return footerTokenState
 	}
 
@@ -174,15 +186,12 @@ func bodyState(l *lexer) stateFunc {
 		return nil
 	}
 
-	// go back to the last newline character
-	for {
-		l.Rewind()
-
-		if l.Peek() != '\n' {
-			break
-		}
+	// go back to the last non newline character
+	end := l.currentPos
+	for l.source[end-1] == '\n' {
+		end--
 	}
-	l.Next()
+	l.Seek(end)
 	l.Emit(bodyToken)
 
 	return bodyDelimiterState
@@ -192,7 +201,7 @@ func bodyDelimiterState(l *lexer) stateFunc {
 	l.Take("\n")
 
 	if len(l.Current()) < 2 {
-		l.Error(errBodyEmptyLine)
+		l.Error(errBodyEmptyLine, insertEdit(l.currentPos, "\n"))
 		return nil
 	}
 
@@ -205,8 +214,14 @@ func footerTokenState(l *lexer) stateFunc {
 	l.Take("\n")
 	l.Ignore()
 
-	checkIfFooterToken(l)
-	l.Emit(footerKeyToken)
+	tok, isBreaking, _ := footerAt(l, l.currentPos)
+	l.Seek(l.currentPos + len(tok))
+
+	if isBreaking {
+		l.Emit(footerBreakingKeyToken)
+	} else {
+		l.Emit(footerKeyToken)
+	}
 
 	return footerDelimiterState
 }
@@ -227,13 +242,16 @@ func footerValueState(l *lexer) stateFunc {
 }
 
 func footerDelimiterState(l *lexer) stateFunc {
-	l.Take(": #")
+	sep := separatorAt(l, l.currentPos)
+	l.Seek(l.currentPos + len(sep))
 	l.Emit(footerDelimterToken)
 
 	return footerValueState
 }
 
 // takeUntilFirstFooter takes all characters until a footer token is detected
+// at the start of a line. If one is found, the lexer is left at the start of
+// that line.
 func takeUntilFirstFooterToken(l *lexer) bool {
 	for {
 		r := l.Next()
@@ -244,84 +262,68 @@ func takeUntilFirstFooterToken(l *lexer) bool {
 
 		// a footer token has to begin at the start of a line
 		if r == '\n' {
-			count, isFooter := checkIfFooterToken(l)
-			if isFooter {
-				// if count is > 0 we are at the end of the footer token
-				// for i := 0; i < count; i++ {
-				// 	l.Rewind()
-				// }
-				rewind(l, count)
-
+			if _, _, found := footerAt(l, l.currentPos); found {
 				return true
 			}
 		}
 	}
 }
 
-func rewind(l *lexer, count int) {
-	for i := count; i > 0; i-- {
-		l.Rewind()
-	}
-}
-
-// takeFooterToken continues over each consecutive character in the source
-// until an invalid footer token character is detected. The method returns
-// the length if there is a valid footer token found. If it is not a valid footer
-// token, 0 is returned.
+// footerAt checks whether a footer token followed by a separator begins at
+// pos, without moving the lexer. It returns the token and whether it is one
+// of the breaking change tokens. Breaking change tokens must be followed by
+// ": ", lines starting with them and another separator are no footers.
 
 // token: "BREAKING CHANGE" | "BREAKING-CHANGE" | <any UTF8-octets except newline or parens or ":" or "!:" or whitespace>+
-func checkIfFooterToken(l *lexer) (int, bool) {
-	// handle BREAKING CHANGE
-	if l.Peek() == 'B' {
-		// BREAKING-CHANGE: or BREAKING CHANGE:
-		candidate := peekString(l, len(breakingTokenSpace)+2)
-		if candidate == breakingTokenSpace+": " || candidate == breakingTokenHyphen+": " {
-			l.Emit(breakingChangeToken)
-			l.Take(breakingTokenSpace + "-")
-			return len(breakingTokenSpace), true
+func footerAt(l *lexer, pos int) (string, bool, bool) {
+	src := l.source[pos:]
+
+	// BREAKING-CHANGE: or BREAKING CHANGE:
+	for _, tok := range l.breakingTokens {
+		if !strings.HasPrefix(src, tok) {
+			continue
+		}
+		if strings.HasPrefix(src[len(tok):], footerSeparatorColon) {
+			return tok, true, true
+		}
+		if separatorAt(l, pos+len(tok)) != "" {
+			return "", false, false
 		}
 	}
 
-	count := 0
-	r := l.Next()
-
-	for unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' {
-		count++
-		r = l.Next()
+	n := 0
+	for n < len(src) {
+		r, size := utf8.DecodeRuneInString(src[n:])
+		if !isValidFooterTokenChar(r) {
+			break
+		}
+		n += size
 	}
 
-	nextChar := l.Peek()
-
-	// :<space> or <space># delimiter
-	if (r == ':' && nextChar == ' ') || (r == ' ' && nextChar == '#') {
-
-		l.Rewind()
-		return count, true
+	if n == 0 || separatorAt(l, pos+n) == "" {
+		return "", false, false
 	}
 
-	l.Rewind()
-
-	return 0, false
+	return src[:n], false, true
 }
 
-func peekString(l *lexer, count int) string {
-	s := ""
-	i := 0
-
-	defer func() {
-		for j := 0; j < i; j++ {
-			l.Rewind()
-		}
-	}()
+// separatorAt returns the footer separator beginning at pos, or an empty
+// string if there is none. Separators are sorted longest first, so ": " is
+// preferred over ":".
 
-	for i = 0; i < count; i++ {
-		if l.Peek() == eof {
-			return s
+// separator: ": " | " #"
+func separatorAt(l *lexer, pos int) string {
+	for _, sep := range l.footerSeparators {
+		if strings.HasPrefix(l.source[pos:], sep) {
+			return sep
 		}
-		s += string(l.Next())
 	}
+	return ""
+}
 
-	return s
+// isBreakingToken reports whether tok is one of the breaking change tokens of the specification
+func isBreakingToken(tok string) bool {
+	return tok == breakingTokenSpace || tok == breakingTokenHyphen
 }
 
 // from https://github.com/conventional-commits/parser#the-grammar
@@ -349,3 +351,7 @@ func isValidScopeChar(r rune) bool {
 		return true
 	}
 }
+
+func isValidFooterTokenChar(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-'
+}
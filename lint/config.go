@@ -0,0 +1,366 @@
+package lint
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConventionalPreset is the name of the preset returned by Conventional
+const ConventionalPreset = "@commitlint/config-conventional"
+
+// maxExtendsDepth limits nested extends of configuration files
+const maxExtendsDepth = 10
+
+// configFiles are the commitlint configuration files in the order commitlint looks them up
+var configFiles = []string{
+	"package.json",
+	".commitlintrc",
+	".commitlintrc.json",
+	".commitlintrc.yaml",
+	".commitlintrc.yml",
+	".commitlintrc.js",
+	".commitlintrc.cjs",
+	".commitlintrc.mjs",
+	".commitlintrc.ts",
+	"commitlint.config.js",
+	"commitlint.config.cjs",
+	"commitlint.config.mjs",
+	"commitlint.config.ts",
+}
+
+var (
+	errConfigNotFound = errors.New("commitlint configuration not found")
+	errRulesNotObject = errors.New("rules must be an object")
+
+	errConfigParse       = "%s: %w"
+	errConfigScript      = "%s: only static object literals exported with module.exports or export default are supported"
+	errConfigExtends     = "%s: unknown extends %q"
+	errConfigExtendDepth = "%s: too many nested extends"
+	errRuleDefinition    = "rule %q: %s"
+)
+
+// Config is a commitlint configuration
+type Config struct {
+	// Path is the file the configuration was loaded from
+	Path string
+	// Extends are the presets and files the configuration extends
+	Extends []string
+	// Rules are the rule definitions in the order of the file
+	Rules []RuleConfig
+}
+
+// RuleConfig is a commitlint rule definition, like [2, "always", ["feat", "fix"]]
+type RuleConfig struct {
+	Name     string
+	Severity Severity
+	When     Condition
+	// Value is the decoded value, like a string, an int or a []interface{}
+	Value interface{}
+}
+
+// UnsupportedRule is a rule definition without an equivalent Go rule
+type UnsupportedRule struct {
+	Name   string
+	Reason string
+}
+
+// UnsupportedRulesError is returned by Config.Settings if some rules are not supported
+type UnsupportedRulesError struct {
+	Rules []UnsupportedRule
+}
+
+// Error lists the unsupported rules
+func (e *UnsupportedRulesError) Error() string {
+	msgs := make([]string, len(e.Rules))
+	for i, r := range e.Rules {
+		msgs[i] = fmt.Sprintf("%s (%s)", r.Name, r.Reason)
+	}
+	return "unsupported commitlint rules: " + strings.Join(msgs, ", ")
+}
+
+// FindConfig returns the path of the commitlint configuration file in dir.
+// package.json is only used if it has a "commitlint" key.
+func FindConfig(dir string) (string, error) {
+	for _, name := range configFiles {
+		path := filepath.Join(dir, name)
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+
+		if name == "package.json" {
+			var pkg map[string]json.RawMessage
+			if json.Unmarshal(data, &pkg) != nil || pkg["commitlint"] == nil {
+				continue
+			}
+		}
+
+		return path, nil
+	}
+
+	return "", errConfigNotFound
+}
+
+// LoadConfig loads a commitlint configuration file. JSON and YAML files are
+// supported, as well as package.json with a "commitlint" key. JavaScript and
+// TypeScript files are only supported if they export a static object literal.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case filepath.Base(path) == "package.json":
+		var pkg struct {
+			Commitlint json.RawMessage `json:"commitlint"`
+		}
+		if err := json.Unmarshal(data, &pkg); err != nil {
+			return nil, fmt.Errorf(errConfigParse, path, err)
+		}
+		data = pkg.Commitlint
+	case isScriptConfig(path):
+		literal, ok := exportedObjectLiteral(string(data))
+		if !ok {
+			return nil, fmt.Errorf(errConfigScript, path)
+		}
+		data = []byte(literal)
+	}
+
+	c, err := ParseConfig(data)
+	if err != nil {
+		return nil, fmt.Errorf(errConfigParse, path, err)
+	}
+	c.Path = path
+
+	return c, nil
+}
+
+// ParseConfig parses a commitlint configuration in JSON or YAML
+func ParseConfig(data []byte) (*Config, error) {
+	var raw struct {
+		Extends yaml.Node `yaml:"extends"`
+		Rules   yaml.Node `yaml:"rules"`
+	}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	c := &Config{}
+
+	switch raw.Extends.Kind {
+	case 0:
+	case yaml.ScalarNode:
+		c.Extends = []string{raw.Extends.Value}
+	default:
+		if err := raw.Extends.Decode(&c.Extends); err != nil {
+			return nil, err
+		}
+	}
+
+	if raw.Rules.Kind != 0 && raw.Rules.Kind != yaml.MappingNode {
+		return nil, errRulesNotObject
+	}
+
+	for i := 0; i+1 < len(raw.Rules.Content); i += 2 {
+		rc, err := parseRuleConfig(raw.Rules.Content[i].Value, raw.Rules.Content[i+1])
+		if err != nil {
+			return nil, err
+		}
+		c.Rules = append(c.Rules, rc)
+	}
+
+	return c, nil
+}
+
+func parseRuleConfig(name string, node *yaml.Node) (RuleConfig, error) {
+	rc := RuleConfig{Name: name}
+
+	var def []interface{}
+	if err := node.Decode(&def); err != nil {
+		return rc, fmt.Errorf(errRuleDefinition, name, "expected [level, 'always'|'never', value]")
+	}
+	if len(def) == 0 {
+		return rc, fmt.Errorf(errRuleDefinition, name, "missing level")
+	}
+
+	level, ok := def[0].(int)
+	if !ok || level < int(SeverityOff) || level > int(SeverityError) {
+		return rc, fmt.Errorf(errRuleDefinition, name, "level must be 0, 1 or 2")
+	}
+	rc.Severity = Severity(level)
+
+	if len(def) > 1 {
+		switch def[1] {
+		case "always":
+			rc.When = Always
+		case "never":
+			rc.When = Never
+		default:
+			return rc, fmt.Errorf(errRuleDefinition, name, "condition must be 'always' or 'never'")
+		}
+	}
+
+	if len(def) > 2 {
+		rc.Value = def[2]
+	}
+
+	return rc, nil
+}
+
+// Settings returns the settings of the extended presets and files, overridden
+// by the rules of the configuration. If some rules have no Go equivalent, the
+// settings of all other rules are returned with an *UnsupportedRulesError.
+func (c *Config) Settings() ([]Setting, error) {
+	return c.settings(0)
+}
+
+func (c *Config) settings(depth int) ([]Setting, error) {
+	var settings []Setting
+	unsupported := &UnsupportedRulesError{}
+
+	for _, ext := range c.Extends {
+		extended, err := c.extend(ext, depth)
+		var uerr *UnsupportedRulesError
+		if errors.As(err, &uerr) {
+			unsupported.Rules = append(unsupported.Rules, uerr.Rules...)
+		} else if err != nil {
+			return nil, err
+		}
+
+		for _, s := range extended {
+			settings = mergeSetting(settings, s)
+		}
+	}
+
+	for _, rc := range c.Rules {
+		rule, err := rc.Rule()
+		if err != nil {
+			unsupported.Rules = append(unsupported.Rules, UnsupportedRule{Name: rc.Name, Reason: err.Error()})
+			continue
+		}
+		settings = mergeSetting(settings, Setting{Severity: rc.Severity, Rule: rule})
+	}
+
+	if len(unsupported.Rules) > 0 {
+		return settings, unsupported
+	}
+	return settings, nil
+}
+
+// extend returns the settings of a preset or a relative configuration file
+func (c *Config) extend(ext string, depth int) ([]Setting, error) {
+	switch ext {
+	case ConventionalPreset, "config-conventional", "conventional":
+		return Conventional(), nil
+	}
+
+	if !strings.HasPrefix(ext, "./") && !strings.HasPrefix(ext, "../") {
+		return nil, fmt.Errorf(errConfigExtends, c.Path, ext)
+	}
+
+	if depth >= maxExtendsDepth {
+		return nil, fmt.Errorf(errConfigExtendDepth, c.Path)
+	}
+
+	extended, err := LoadConfig(filepath.Join(filepath.Dir(c.Path), ext))
+	if err != nil {
+		return nil, err
+	}
+	return extended.settings(depth + 1)
+}
+
+// mergeSetting replaces the setting of the same rule name, or appends s
+func mergeSetting(settings []Setting, s Setting) []Setting {
+	for i := range settings {
+		if settings[i].Rule.Name() == s.Rule.Name() {
+			settings[i] = s
+			return settings
+		}
+	}
+	return append(settings, s)
+}
+
+func isScriptConfig(path string) bool {
+	switch filepath.Ext(path) {
+	case ".js", ".cjs", ".mjs", ".ts":
+		return true
+	default:
+		return false
+	}
+}
+
+// exportedObjectLiteral returns the object literal exported by a script,
+// without comments. Object literals without spread or computed values are
+// valid YAML flow mappings.
+func exportedObjectLiteral(script string) (string, bool) {
+	script = stripScriptComments(script)
+
+	start := -1
+	for _, export := range []string{"module.exports", "export default"} {
+		if i := strings.Index(script, export); i >= 0 {
+			start = i + len(export)
+			break
+		}
+	}
+	if start < 0 {
+		return "", false
+	}
+
+	rest := strings.TrimLeft(strings.TrimPrefix(strings.TrimSpace(script[start:]), "="), " \t\r\n")
+	if !strings.HasPrefix(rest, "{") {
+		return "", false
+	}
+
+	end := strings.LastIndex(rest, "}")
+	if end < 0 {
+		return "", false
+	}
+	return rest[:end+1], true
+}
+
+// stripScriptComments removes // and /* */ comments outside of string literals
+func stripScriptComments(script string) string {
+	var b strings.Builder
+	var quote byte
+
+	for i := 0; i < len(script); i++ {
+		ch := script[i]
+
+		switch {
+		case quote != 0:
+			b.WriteByte(ch)
+			if ch == '\\' && i+1 < len(script) {
+				i++
+				b.WriteByte(script[i])
+			} else if ch == quote {
+				quote = 0
+			}
+		case ch == '"' || ch == '\'' || ch == '`':
+			quote = ch
+			b.WriteByte(ch)
+		case strings.HasPrefix(script[i:], "//"):
+			for i < len(script) && script[i] != '\n' {
+				i++
+			}
+			b.WriteByte('\n')
+		case strings.HasPrefix(script[i:], "/*"):
+			end := strings.Index(script[i+2:], "*/")
+			if end < 0 {
+				return b.String()
+			}
+			i += end + 3
+		default:
+			b.WriteByte(ch)
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,188 @@
+package lint
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	errRuleUnknown       = errors.New("no equivalent rule")
+	errRuleValueString   = "value must be a string, got %v"
+	errRuleValueStrings  = "value must be a string or a list of strings, got %v"
+	errRuleValueInt      = "value must be a number, got %v"
+	errRuleUnknownCase   = "unknown case %q"
+	errRuleUnknownTarget = "%s rules are not supported for %s"
+)
+
+// ruleKinds are the suffixes of rule names and the parts they support
+var ruleKinds = []struct {
+	suffix string
+	parts  []Part
+}{
+	{"-max-line-length", []Part{PartBody, PartFooter}},
+	{"-max-length", []Part{PartType, PartScope, PartSubject, PartHeader, PartBody, PartFooter}},
+	{"-min-length", []Part{PartType, PartScope, PartSubject, PartHeader, PartBody, PartFooter}},
+	{"-enum", []Part{PartType, PartScope}},
+	{"-empty", []Part{PartType, PartScope, PartSubject, PartBody, PartFooter}},
+	{"-full-stop", []Part{PartSubject, PartHeader, PartBody}},
+	{"-leading-blank", []Part{PartBody, PartFooter}},
+	{"-case", []Part{PartType, PartScope, PartSubject, PartHeader, PartBody}},
+}
+
+// Rule returns the Go rule equivalent to the definition. The value of rules
+// turned off with SeverityOff is not read, like commitlint's [0].
+func (rc RuleConfig) Rule() (Rule, error) {
+	switch rc.Name {
+	case "subject-exclamation-mark":
+		return &ExclamationMark{When: rc.When}, nil
+	case "header-trim":
+		return &HeaderTrim{}, nil
+	case "references-empty":
+		return &ReferencesEmpty{When: rc.When}, nil
+	case "trailer-exists":
+		value, err := rc.stringValue()
+		if err != nil {
+			return nil, err
+		}
+		return &TrailerExists{When: rc.When, Value: value}, nil
+	case "signed-off-by":
+		value, err := rc.stringValue()
+		if err != nil {
+			return nil, err
+		}
+		return &SignedOffBy{When: rc.When, Value: value}, nil
+	}
+
+	for _, kind := range ruleKinds {
+		if !strings.HasSuffix(rc.Name, kind.suffix) {
+			continue
+		}
+
+		name := strings.TrimSuffix(rc.Name, kind.suffix)
+		for _, part := range kind.parts {
+			if part.String() == name {
+				return rc.partRule(kind.suffix, part)
+			}
+		}
+		return nil, fmt.Errorf(errRuleUnknownTarget, strings.TrimPrefix(kind.suffix, "-"), name)
+	}
+
+	return nil, errRuleUnknown
+}
+
+func (rc RuleConfig) partRule(suffix string, part Part) (Rule, error) {
+	switch suffix {
+	case "-enum":
+		values, err := rc.stringsValue()
+		if err != nil {
+			return nil, err
+		}
+		return &Enum{Part: part, When: rc.When, Values: values}, nil
+	case "-empty":
+		return &Empty{Part: part, When: rc.When}, nil
+	case "-max-length":
+		max, err := rc.intValue()
+		if err != nil {
+			return nil, err
+		}
+		return &MaxLength{Part: part, Max: max}, nil
+	case "-min-length":
+		min, err := rc.intValue()
+		if err != nil {
+			return nil, err
+		}
+		return &MinLength{Part: part, Min: min}, nil
+	case "-max-line-length":
+		max, err := rc.intValue()
+		if err != nil {
+			return nil, err
+		}
+		return &MaxLineLength{Part: part, Max: max}, nil
+	case "-full-stop":
+		value := ""
+		if rc.Value != nil {
+			v, err := rc.stringValue()
+			if err != nil {
+				return nil, err
+			}
+			value = v
+		}
+		return &FullStop{Part: part, When: rc.When, Value: value}, nil
+	case "-leading-blank":
+		return &LeadingBlank{Part: part, When: rc.When}, nil
+	case "-case":
+		cases, err := rc.stringsValue()
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range cases {
+			if !isKnownCase(name) {
+				return nil, fmt.Errorf(errRuleUnknownCase, name)
+			}
+		}
+		return &Case{Part: part, When: rc.When, Cases: cases}, nil
+	default:
+		return nil, errRuleUnknown
+	}
+}
+
+// stringValue returns the string value, empty if the rule is off
+func (rc RuleConfig) stringValue() (string, error) {
+	if rc.Severity == SeverityOff {
+		return "", nil
+	}
+	return toString(rc.Value)
+}
+
+// stringsValue returns the string or list of strings value, nil if the
+// rule is off
+func (rc RuleConfig) stringsValue() ([]string, error) {
+	if rc.Severity == SeverityOff {
+		return nil, nil
+	}
+	return toStrings(rc.Value)
+}
+
+// intValue returns the number value, 0 if the rule is off
+func (rc RuleConfig) intValue() (int, error) {
+	if rc.Severity == SeverityOff {
+		return 0, nil
+	}
+	return toInt(rc.Value)
+}
+
+func toString(v interface{}) (string, error) {
+	s, ok := v.(string)
+	if !ok {
+		return "", fmt.Errorf(errRuleValueString, v)
+	}
+	return s, nil
+}
+
+func toStrings(v interface{}) ([]string, error) {
+	switch value := v.(type) {
+	case string:
+		return []string{value}, nil
+	case []interface{}:
+		values := make([]string, len(value))
+		for i, item := range value {
+			s, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf(errRuleValueStrings, v)
+			}
+			values[i] = s
+		}
+		return values, nil
+	default:
+		return nil, fmt.Errorf(errRuleValueStrings, v)
+	}
+}
+
+func toInt(v interface{}) (int, error) {
+	n, ok := v.(int)
+	if !ok {
+		return 0, fmt.Errorf(errRuleValueInt, v)
+	}
+	return n, nil
+}
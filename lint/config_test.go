@@ -0,0 +1,171 @@
+package lint
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const configDir = "testdata/config"
+
+func TestLoadConfig(t *testing.T) {
+	for _, dir := range []string{"json", "yaml", "script", "package", "extends"} {
+		dir := dir
+		t.Run(dir, func(innerT *testing.T) {
+			path, err := FindConfig(filepath.Join(configDir, dir))
+			if err != nil {
+				innerT.Fatal(err)
+			}
+
+			c, err := LoadConfig(path)
+			if err != nil {
+				innerT.Fatal(err)
+			}
+
+			settings, err := c.Settings()
+			if err != nil {
+				innerT.Fatal(err)
+			}
+
+			l := New(nil, settings...)
+
+			r := l.Lint("feat(db): add x")
+			if len(r.Errors()) != 1 || r.Errors()[0].Rule != "scope-enum" {
+				innerT.Errorf("expected scope-enum error, got %v", r.Diagnostics)
+			}
+
+			r = l.Lint("feat(api): add a description longer than seventy two characters to the header")
+			if len(r.Warnings()) != 1 || r.Warnings()[0].Rule != "header-max-length" {
+				innerT.Errorf("expected header-max-length warning, got %v", r.Diagnostics)
+			}
+
+			r = l.Lint("Feat(api): add x.")
+			if len(r.Errors()) != 3 {
+				innerT.Errorf("expected preset errors, got %v", r.Diagnostics)
+			}
+
+			for _, s := range settings {
+				if s.Rule.Name() == "body-leading-blank" && s.Severity != SeverityOff {
+					innerT.Error("body-leading-blank not disabled")
+				}
+			}
+		})
+	}
+}
+
+func TestLoadConfigUnsupported(t *testing.T) {
+	c, err := LoadConfig(filepath.Join(configDir, "unsupported", ".commitlintrc.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	settings, err := c.Settings()
+
+	var uerr *UnsupportedRulesError
+	if !errors.As(err, &uerr) {
+		t.Fatalf("expected UnsupportedRulesError, got %v", err)
+	}
+
+	var names []string
+	for _, r := range uerr.Rules {
+		names = append(names, r.Name)
+	}
+	if len(names) != 3 || names[0] != "footer-case" || names[1] != "function-rules/header-max-length" || names[2] != "body-enum" {
+		t.Errorf("unexpected unsupported rules %v", names)
+	}
+
+	if len(settings) != 1 || settings[0].Rule.Name() != "type-enum" {
+		t.Errorf("expected supported rules to be returned, got %v", settings)
+	}
+}
+
+func TestConfigRulesOff(t *testing.T) {
+	c, err := ParseConfig([]byte(`{
+		"extends": ["@commitlint/config-conventional"],
+		"rules": {
+			"subject-case": [0],
+			"body-max-line-length": [0, "always"],
+			"trailer-exists": [0, "always"]
+		}
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	settings, err := c.Settings()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	off := make(map[string]bool)
+	for _, s := range settings {
+		off[s.Rule.Name()] = s.Severity == SeverityOff
+	}
+	for _, name := range []string{"subject-case", "body-max-line-length", "trailer-exists"} {
+		if !off[name] {
+			t.Errorf("%s not turned off", name)
+		}
+	}
+
+	r := New(nil, settings...).Lint("feat: Add X\n\n" + strings.Repeat("long ", 30))
+	if len(r.Diagnostics) != 0 {
+		t.Errorf("expected no diagnostics, got %v", r.Diagnostics)
+	}
+}
+
+func TestConfigReferencesAndTrim(t *testing.T) {
+	c, err := ParseConfig([]byte(`{
+		"rules": {
+			"references-empty": [2, "never"],
+			"header-trim": [2, "always"]
+		}
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	settings, err := c.Settings()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	l := New(nil, settings...)
+	if r := l.Lint("feat: x \n\nRefs: #12"); len(r.Errors()) != 1 || r.Errors()[0].Rule != "header-trim" {
+		t.Errorf("expected header-trim error, got %v", r.Diagnostics)
+	}
+	if r := l.Lint("feat: x"); len(r.Errors()) != 1 || r.Errors()[0].Rule != "references-empty" {
+		t.Errorf("expected references-empty error, got %v", r.Diagnostics)
+	}
+}
+
+func TestParseConfigInvalid(t *testing.T) {
+	var cases = []string{
+		`{"rules": {"type-enum": "feat"}}`,
+		`{"rules": {"type-enum": []}}`,
+		`{"rules": {"type-enum": [3, "always", ["feat"]]}}`,
+		`{"rules": {"type-enum": [2, "sometimes", ["feat"]]}}`,
+		`{"rules": ["type-enum"]}`,
+	}
+
+	for _, data := range cases {
+		if _, err := ParseConfig([]byte(data)); err == nil {
+			t.Errorf("ParseConfig(%s) passed without error", data)
+		}
+	}
+}
+
+func TestRuleConfigInvalidValue(t *testing.T) {
+	var cases = []RuleConfig{
+		{Name: "header-max-length", Severity: SeverityError, Value: "72"},
+		{Name: "type-enum", Severity: SeverityError, Value: 1},
+		{Name: "type-case", Severity: SeverityError, Value: "no-case"},
+		{Name: "trailer-exists", Severity: SeverityWarning},
+	}
+
+	for _, rc := range cases {
+		if _, err := rc.Rule(); err == nil {
+			t.Errorf("%s with value %v passed without error", rc.Name, rc.Value)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package lint
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/conventionalcommit/parser"
+)
+
+// maxFixPasses limits how often a message is linted again to fix problems
+// that only show up after other fixes, like lint rules after a parse error
+const maxFixPasses = 10
+
+// Fix is a machine applicable correction of a problem
+type Fix struct {
+	// Rule is the name of the rule that reported the problem
+	Rule string
+	// Edits are applied together, positions refer to the linted message
+	Edits []parser.Edit
+}
+
+func newFix(rule string, edits []parser.Edit) *Fix {
+	if len(edits) == 0 {
+		return nil
+	}
+	return &Fix{
+		Rule:  rule,
+		Edits: edits,
+	}
+}
+
+// Fix applies the fixes of all diagnostics of the message and returns the
+// corrected message with the fixes applied. Overlapping fixes are skipped,
+// and the message is linted again until no more fixes apply. A pass of fixes
+// that makes a valid message unparsable is discarded, and fixing stops if
+// contradicting rules would produce a message again.
+//
+// The returned message is not guaranteed to be valid: problems without a
+// fix remain, and a message that did not parse may still fail to parse.
+// Lint the returned message to check it.
+func (l *Linter) Fix(message string) (string, []Fix) {
+	fixed := strings.TrimSpace(message)
+	var applied []Fix
+
+	seen := map[string]bool{fixed: true}
+
+	for pass := 0; pass < maxFixPasses; pass++ {
+		r := l.Lint(fixed)
+
+		fixes := nonOverlappingFixes(r.Diagnostics)
+		if len(fixes) == 0 {
+			break
+		}
+
+		candidate := strings.TrimSpace(applyFixes(fixed, fixes))
+		if seen[candidate] {
+			break
+		}
+		seen[candidate] = true
+
+		if _, err := l.parser.Parse(candidate); err != nil && r.Commit != nil {
+			break
+		}
+
+		fixed = candidate
+		applied = append(applied, fixes...)
+	}
+
+	return fixed, applied
+}
+
+// nonOverlappingFixes returns the fixes of the diagnostics, skipping fixes
+// with edits overlapping the edits of earlier fixes
+func nonOverlappingFixes(diags []Diagnostic) []Fix {
+	var fixes []Fix
+	var accepted []parser.Edit
+
+	for _, d := range diags {
+		if d.Fix == nil || overlapsAny(d.Fix.Edits, accepted) {
+			continue
+		}
+		fixes = append(fixes, *d.Fix)
+		accepted = append(accepted, d.Fix.Edits...)
+	}
+
+	return fixes
+}
+
+func overlapsAny(edits, accepted []parser.Edit) bool {
+	for _, e := range edits {
+		for _, a := range accepted {
+			if overlaps(e.Span, a.Span) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// overlaps reports whether two spans overlap, insertions at the same
+// position overlap as their order would be ambiguous
+func overlaps(a, b parser.Span) bool {
+	if a.Start == b.Start {
+		return true
+	}
+	return a.Start < b.End && b.Start < a.End
+}
+
+// applyFixes applies the edits of all fixes, which must not overlap
+func applyFixes(message string, fixes []Fix) string {
+	var edits []parser.Edit
+	for _, f := range fixes {
+		edits = append(edits, f.Edits...)
+	}
+	return applyEdits(message, edits)
+}
+
+// applyEdits applies non overlapping edits to message
+func applyEdits(message string, edits []parser.Edit) string {
+	sorted := make([]parser.Edit, len(edits))
+	copy(sorted, edits)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Span.Start > sorted[j].Span.Start
+	})
+
+	for _, e := range sorted {
+		message = message[:e.Span.Start] + e.Text + message[e.Span.End:]
+	}
+	return message
+}
@@ -0,0 +1,188 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/conventionalcommit/parser"
+)
+
+func TestLinterFix(t *testing.T) {
+	l := New(parser.New())
+	l.Add(SeverityError, &Case{Part: PartType, Cases: []string{"lower-case"}})
+	l.Add(SeverityError, &FullStop{Part: PartSubject, When: Never})
+	l.Add(SeverityError, &LeadingBlank{Part: PartFooter, When: Always})
+	l.Add(SeverityWarning, &BreakingChangeToken{})
+	l.Add(SeverityError, &MaxLength{Part: PartHeader, Max: 100})
+
+	var cases = []struct {
+		message string
+		fixed   string
+		rules   []string
+	}{
+		{"feat: add x", "feat: add x", nil},
+		{"Feat: add x.", "feat: add x", []string{"type-case", "subject-full-stop"}},
+		{"feat:add x\nbody", "feat: add x\n\nbody", []string{ParseRule, ParseRule}},
+		{"fix: x\n\nBreaking Change: y", "fix: x\n\nBREAKING CHANGE: y", []string{"breaking-change-token"}},
+		{"FIX: x.\n\nbody\n\nRefs: #1", "fix: x\n\nbody\n\nRefs: #1", []string{"type-case", "subject-full-stop"}},
+		{"feat add x", "feat add x", nil},
+	}
+
+	for i, tc := range cases {
+		fixed, fixes := l.Fix(tc.message)
+		if fixed != tc.fixed {
+			t.Errorf("case#%d: Fix(%q) = %q, expected %q", i, tc.message, fixed, tc.fixed)
+		}
+
+		var rules []string
+		for _, f := range fixes {
+			rules = append(rules, f.Rule)
+		}
+		if len(rules) != len(tc.rules) {
+			t.Errorf("case#%d: Fix(%q) applied %v, expected %v", i, tc.message, rules, tc.rules)
+			continue
+		}
+		for j := range rules {
+			if rules[j] != tc.rules[j] {
+				t.Errorf("case#%d: Fix(%q) applied %v, expected %v", i, tc.message, rules, tc.rules)
+				break
+			}
+		}
+	}
+}
+
+func TestLinterFixOverlapping(t *testing.T) {
+	l := New(parser.New())
+	l.Add(SeverityError, &Case{Part: PartSubject, Cases: []string{"upper-case"}})
+	l.Add(SeverityError, &Case{Part: PartSubject, Cases: []string{"lower-case"}})
+
+	fixes := nonOverlappingFixes(l.Lint("feat: Add x").Diagnostics)
+	if len(fixes) != 1 || fixes[0].Edits[0].Text != "ADD X" {
+		t.Errorf("nonOverlappingFixes() = %v, expected first fix only", fixes)
+	}
+
+	// the rules contradict each other, fixing must still terminate
+	if _, applied := l.Fix("feat: Add x"); len(applied) >= maxFixPasses {
+		t.Errorf("Fix() applied %d fixes, expected to stop on repeated messages", len(applied))
+	}
+}
+
+func TestLinterFixCase(t *testing.T) {
+	l := New(parser.New())
+	l.Add(SeverityError, &Case{Part: PartScope, Cases: []string{"kebab-case"}})
+	l.Add(SeverityError, &Case{Part: PartSubject, When: Never, Cases: []string{"sentence-case", "upper-case"}})
+
+	var cases = map[string]string{
+		"feat(UserAPI): Add x":        "feat(user-api): add x",
+		"feat(userAPI,ui/DB): x":      "feat(user-api,ui/db): x",
+		"feat: API support":           "feat: api support",
+		"feat(user-api): add support": "feat(user-api): add support",
+	}
+
+	for msg, expected := range cases {
+		if fixed, _ := l.Fix(msg); fixed != expected {
+			t.Errorf("Fix(%q) = %q, expected %q", msg, fixed, expected)
+		}
+	}
+}
+
+func TestHeaderTrimFix(t *testing.T) {
+	l := New(parser.New(), Setting{SeverityError, &HeaderTrim{}})
+
+	if fixed, _ := l.Fix("feat: x \t\n\nbody"); fixed != "feat: x\n\nbody" {
+		t.Errorf("Fix() = %q, expected trailing whitespace removed", fixed)
+	}
+}
+
+func TestLinterFixWrap(t *testing.T) {
+	l := New(parser.New())
+	l.Add(SeverityError, &MaxLineLength{Part: PartBody, Max: 20})
+	l.Add(SeverityError, &MaxLineLength{Part: PartFooter, Max: 30})
+
+	msg := "fix: x\n\nthis body line is much too long for the limit\n\n- list item\n\nBREAKING CHANGE: the configuration file format changed"
+	expected := "fix: x\n\nthis body line is\nmuch too long for\nthe limit\n\n- list item\n\nBREAKING CHANGE: the\n configuration file format\n changed"
+
+	fixed, fixes := l.Fix(msg)
+	if fixed != expected {
+		t.Errorf("Fix() = %q, expected %q", fixed, expected)
+	}
+	if len(fixes) != 2 {
+		t.Errorf("Fix() applied %v, expected body and footer fix", fixes)
+	}
+}
+
+func TestLinterFixWrapSeparators(t *testing.T) {
+	l := New(parser.New(parser.WithFooterSeparators("=")))
+	l.Add(SeverityError, &MaxLineLength{Part: PartBody, Max: 24})
+
+	msg := "fix: x\n\nthe default value is now Timeout=30 for all clients"
+	expected := "fix: x\n\nthe default value is\nnow Timeout=30 for all\nclients"
+
+	fixed, _ := l.Fix(msg)
+	if fixed != expected {
+		t.Errorf("Fix() = %q, expected %q", fixed, expected)
+	}
+	if r := l.Lint(fixed); len(r.Commit.Notes()) != 0 {
+		t.Errorf("fixed body has notes %v", r.Commit.Notes())
+	}
+}
+
+func TestLintDiagnosticFix(t *testing.T) {
+	r := New(parser.New()).Lint("feat: x\nbody")
+
+	if len(r.Diagnostics) != 1 || r.Diagnostics[0].Fix == nil {
+		t.Fatalf("expected parse diagnostic with fix, got %v", r.Diagnostics)
+	}
+
+	d := r.Diagnostics[0]
+	if d.Span.Start != len("feat: x\n") {
+		t.Errorf("diagnostic span %v, expected start of body", d.Span)
+	}
+	if edits := d.Fix.Edits; len(edits) != 1 || edits[0].Text != "\n" {
+		t.Errorf("diagnostic fix %v, expected blank line insertion", edits)
+	}
+}
+
+func TestImperativeMoodFix(t *testing.T) {
+	l := New(parser.New())
+	l.Add(SeverityWarning, &ImperativeMood{Verbs: []string{"dockerize"}})
+
+	var cases = map[string]string{
+		"feat: added x":         "feat: add x",
+		"feat: Removed x":       "feat: Remove x",
+		"fix: fixes y":          "fix: fix y",
+		"fix: dropped z":        "fix: drop z",
+		"fix: applies patch":    "fix: apply patch",
+		"fix: copied files":     "fix: copy files",
+		"build: dockerized app": "build: dockerize app",
+		"fix(api): adding z":    "fix(api): add z",
+		"fix: making it work":   "fix: make it work",
+		"docs: readme typo":     "docs: readme typo",
+		"fix: bound retries":    "fix: bound retries",
+		"feat: found x":         "feat: found x",
+		"test: tests for x":     "test: tests for x",
+		"chore: logs cleanup":   "chore: logs cleanup",
+	}
+
+	for msg, expected := range cases {
+		if fixed, _ := l.Fix(msg); fixed != expected {
+			t.Errorf("Fix(%q) = %q, expected %q", msg, fixed, expected)
+		}
+	}
+}
+
+func TestKnownScopeSuggestion(t *testing.T) {
+	l := New(parser.New())
+	l.Add(SeverityError, &KnownScope{Scopes: []string{"parser", "lint", "user-api"}})
+
+	r := l.Lint("feat(pasrer,UserAPI): x")
+	if len(r.Diagnostics) != 2 {
+		t.Fatalf("expected 2 diagnostics, got %v", r.Diagnostics)
+	}
+	if msg := r.Diagnostics[0].Message; msg != `scope "pasrer" is unknown, did you mean "parser"?` {
+		t.Errorf("unexpected message %q", msg)
+	}
+
+	if fixed, _ := l.Fix("feat(pasrer,UserAPI): x"); fixed != "feat(parser,user-api): x" {
+		t.Errorf("Fix() = %q", fixed)
+	}
+}
@@ -0,0 +1,192 @@
+package lint
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/conventionalcommit/parser"
+)
+
+// imperativeVerbs are the base forms of verbs common in commit descriptions
+var imperativeVerbs = wordSet(`
+	abort accept access add adapt adjust align allow amend annotate append apply
+	archive assert assign avoid backport ban batch bind block bootstrap bound
+	break bring build bump bundle cache calculate call cancel capture centralize
+	change check clarify clean cleanup clear clone close collapse collect combine
+	comment commit compile complete compress compute configure connect consolidate
+	convert copy correct count create cut debug decouple decrease default defer
+	define delay delegate delete deploy deprecate describe destroy detect disable
+	disallow discard display document downgrade drop dump duplicate edit eliminate
+	embed emit enable encode encrypt enforce enhance ensure escape evaluate exclude
+	execute expand expect explain export expose extend extract fail fetch filter
+	finalize find fix flatten flip flush fold force format forward free generate
+	get group guard handle hide highlight hoist hook ignore implement import
+	improve include increase increment indent inherit initialize inject inline
+	insert install integrate introduce invalidate invert isolate keep kill launch
+	limit link lint list load localize lock log lower make manage map mark match
+	merge migrate minimize mock modify mount move name normalize notify obtain
+	omit open optimize order organize output override parse pass patch pause pin
+	polish populate port prefer prepare prepend present preserve prevent print
+	process promote propagate protect provide prune publish pull push put query
+	queue raise read rebase rebuild recover redesign reduce refactor reference
+	refine reformat refresh register reject release reload remove rename render
+	reorder reorganize repair replace report request require reset resize resolve
+	restore restrict restructure retry return reuse revert review revise rewrite
+	rework run sanitize save scan schedule scroll secure select send separate
+	serialize serve set setup share shorten show simplify skip sort specify split
+	squash stabilize start stop store streamline strip style submit support
+	suppress swap switch sync tag test throw tidy toggle track transform translate
+	trigger trim truncate tune tweak unblock uncomment undo unify uninstall unlock
+	unpin unset update upgrade upload use validate verify wait warn watch wire
+	wrap write yield
+`)
+
+// pluralNouns are plurals of nouns that are also verbs. As first word they
+// are read as nouns, like "tests for x", rather than as third-person verbs.
+var pluralNouns = wordSet(`
+	builds caches changes checks comments commits defaults docs errors exports
+	files formats groups hooks imports links lists locks logs maps marks messages
+	names options orders patches releases reports requests settings styles tags
+	tests types
+`)
+
+// irregularVerbs map past and third-person forms to their base form
+var irregularVerbs = map[string]string{
+	"broke": "break", "brought": "bring", "built": "build",
+	"caught": "catch", "chose": "choose", "did": "do", "does": "do",
+	"drew": "draw", "fed": "feed", "froze": "freeze",
+	"gave": "give", "got": "get", "had": "have", "has": "have",
+	"held": "hold", "hid": "hide", "kept": "keep", "left": "leave",
+	"made": "make", "meant": "mean", "overrode": "override", "ran": "run",
+	"rebuilt": "rebuild", "redid": "redo", "reran": "rerun", "rewrote": "rewrite",
+	"sent": "send", "shook": "shake", "spun": "spin", "stole": "steal",
+	"struck": "strike", "took": "take", "threw": "throw", "told": "tell",
+	"undid": "undo", "went": "go", "wrote": "write",
+}
+
+// ImperativeMood requires the description to start with a verb in
+// imperative mood, like "add" instead of "added", "adds" or "adding". Verbs
+// are recognized with a built-in English lexicon, extended by Verbs. Words
+// in Allowed are never reported, like project jargon. The fix replaces the
+// first word with its imperative form.
+//
+// Name: subject-imperative-mood
+type ImperativeMood struct {
+	Verbs   []string
+	Allowed []string
+}
+
+// Name returns the name of the rule
+func (r *ImperativeMood) Name() string {
+	return "subject-imperative-mood"
+}
+
+// Check checks the rule
+func (r *ImperativeMood) Check(c *parser.Commit) []Problem {
+	desc := c.Description()
+	end := strings.IndexFunc(desc, func(r rune) bool { return !unicode.IsLetter(r) && r != '-' })
+	if end < 0 {
+		end = len(desc)
+	}
+
+	word := desc[:end]
+	lower := strings.ToLower(word)
+	if word == "" || containsFold(r.Allowed, lower) || r.isVerb(lower) {
+		return nil
+	}
+
+	base, ok := r.imperative(lower)
+	if !ok {
+		return nil
+	}
+
+	start := c.DescriptionSpan().Start
+	span := parser.Span{Start: start, End: start + len(word)}
+	return []Problem{{
+		Message: fmt.Sprintf("subject must use imperative mood, %q instead of %q", base, lower),
+		Span:    span,
+		Edits:   []parser.Edit{{Span: span, Text: matchCapital(word, base)}},
+	}}
+}
+
+func (r *ImperativeMood) isVerb(word string) bool {
+	return imperativeVerbs[word] || containsFold(r.Verbs, word)
+}
+
+// imperative returns the base form of a past tense, third-person or gerund
+// form of a known verb
+func (r *ImperativeMood) imperative(word string) (string, bool) {
+	if base, ok := irregularVerbs[word]; ok {
+		return base, true
+	}
+	if pluralNouns[word] {
+		return "", false
+	}
+
+	for _, base := range baseCandidates(word) {
+		if r.isVerb(base) {
+			return base, true
+		}
+	}
+	return "", false
+}
+
+// baseCandidates returns the possible base forms of a regular verb form
+func baseCandidates(word string) []string {
+	var candidates []string
+
+	for _, suffix := range []string{"ed", "ing"} {
+		stem := strings.TrimSuffix(word, suffix)
+		if stem == word || stem == "" {
+			continue
+		}
+
+		candidates = append(candidates, stem, stem+"e")
+		if n := len(stem); n > 1 && stem[n-1] == stem[n-2] {
+			candidates = append(candidates, stem[:n-1])
+		}
+		if suffix == "ed" && strings.HasSuffix(stem, "i") {
+			candidates = append(candidates, strings.TrimSuffix(stem, "i")+"y")
+		}
+	}
+
+	switch {
+	case strings.HasSuffix(word, "ies"):
+		candidates = append(candidates, strings.TrimSuffix(word, "ies")+"y")
+	case strings.HasSuffix(word, "es"):
+		candidates = append(candidates, strings.TrimSuffix(word, "es"), strings.TrimSuffix(word, "s"))
+	case strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss"):
+		candidates = append(candidates, strings.TrimSuffix(word, "s"))
+	}
+
+	return candidates
+}
+
+// matchCapital capitalizes the first letter of word if original starts with a capital letter
+func matchCapital(original, word string) string {
+	first, _ := utf8.DecodeRuneInString(original)
+	if !unicode.IsUpper(first) {
+		return word
+	}
+	r, size := utf8.DecodeRuneInString(word)
+	return string(unicode.ToUpper(r)) + word[size:]
+}
+
+func containsFold(values []string, v string) bool {
+	for _, value := range values {
+		if strings.EqualFold(value, v) {
+			return true
+		}
+	}
+	return false
+}
+
+func wordSet(words string) map[string]bool {
+	set := map[string]bool{}
+	for _, w := range strings.Fields(words) {
+		set[w] = true
+	}
+	return set
+}
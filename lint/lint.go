@@ -0,0 +1,213 @@
+// Package lint checks conventional commits against configurable rules
+package lint
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/conventionalcommit/parser"
+)
+
+// ParseRule is the rule name of diagnostics reported for messages that cannot be parsed
+const ParseRule = "parse"
+
+// Severity is the level a rule is reported with
+type Severity int
+
+const (
+	// SeverityOff disables a rule
+	SeverityOff Severity = iota
+	// SeverityWarning reports a rule violation without failing
+	SeverityWarning
+	// SeverityError reports a rule violation as failure
+	SeverityError
+)
+
+// String returns the name of the severity
+func (s Severity) String() string {
+	switch s {
+	case SeverityOff:
+		return "off"
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	default:
+		return fmt.Sprintf("Severity(%d)", int(s))
+	}
+}
+
+// Condition selects whether a rule must or must not match
+type Condition int
+
+const (
+	// Always requires the rule to match
+	Always Condition = iota
+	// Never requires the rule not to match
+	Never
+)
+
+// String returns the name of the condition
+func (c Condition) String() string {
+	switch c {
+	case Always:
+		return "always"
+	case Never:
+		return "never"
+	default:
+		return fmt.Sprintf("Condition(%d)", int(c))
+	}
+}
+
+// Rule checks a parsed commit
+type Rule interface {
+	// Name returns the name of the rule, like "type-enum"
+	Name() string
+	// Check returns the problems found in the commit. The raw message and
+	// the positions of its parts are available from c.
+	Check(c *parser.Commit) []Problem
+}
+
+// Problem is a violation of a rule
+type Problem struct {
+	// Message describes the problem
+	Message string
+	// Span is the position of the problem in the commit message
+	Span parser.Span
+	// Edits fix the problem, empty if it cannot be fixed automatically
+	Edits []parser.Edit
+}
+
+// Diagnostic is a problem reported by the Linter
+type Diagnostic struct {
+	// Rule is the name of the rule that reported the problem
+	Rule string
+	// Severity is the severity the rule is enabled with
+	Severity Severity
+	// Message describes the problem
+	Message string
+	// Span is the position of the problem in the commit message
+	Span parser.Span
+	// Fix corrects the problem, nil if it cannot be fixed automatically
+	Fix *Fix
+}
+
+// String returns the diagnostic in the form "severity: message [rule]"
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%s: %s [%s]", d.Severity, d.Message, d.Rule)
+}
+
+// Setting enables a rule with a severity
+type Setting struct {
+	Severity Severity
+	Rule     Rule
+}
+
+// Linter checks commit messages against a set of rules
+type Linter struct {
+	parser   *parser.Parser
+	settings []Setting
+}
+
+// New returns a Linter checking messages parsed with p against the given
+// settings. If p is nil, a default parser is used.
+func New(p *parser.Parser, settings ...Setting) *Linter {
+	if p == nil {
+		p = parser.New()
+	}
+	return &Linter{
+		parser:   p,
+		settings: settings,
+	}
+}
+
+// Add enables a rule with the given severity
+func (l *Linter) Add(severity Severity, rule Rule) {
+	l.settings = append(l.settings, Setting{Severity: severity, Rule: rule})
+}
+
+// Settings returns the enabled rules
+func (l *Linter) Settings() []Setting {
+	return l.settings
+}
+
+// Lint parses the message and checks it against all rules. If the message
+// cannot be parsed, the result has a single diagnostic of ParseRule.
+func (l *Linter) Lint(message string) *Result {
+	r := &Result{
+		Message: strings.TrimSpace(message),
+	}
+
+	c, err := l.parser.Parse(message)
+	if err != nil {
+		d := Diagnostic{
+			Rule:     ParseRule,
+			Severity: SeverityError,
+			Message:  err.Error(),
+		}
+
+		var perr *parser.Error
+		if errors.As(err, &perr) {
+			d.Span = perr.Span
+			d.Fix = newFix(ParseRule, perr.Edits)
+		}
+
+		r.Diagnostics = append(r.Diagnostics, d)
+		return r
+	}
+	r.Commit = c
+
+	for _, s := range l.settings {
+		if s.Severity == SeverityOff {
+			continue
+		}
+
+		for _, p := range s.Rule.Check(c) {
+			r.Diagnostics = append(r.Diagnostics, Diagnostic{
+				Rule:     s.Rule.Name(),
+				Severity: s.Severity,
+				Message:  p.Message,
+				Span:     p.Span,
+				Fix:      newFix(s.Rule.Name(), p.Edits),
+			})
+		}
+	}
+
+	return r
+}
+
+// Result is the outcome of linting a message
+type Result struct {
+	// Message is the linted message, spans are relative to it
+	Message string
+	// Commit is the parsed commit, nil if the message cannot be parsed
+	Commit *parser.Commit
+	// Diagnostics are the problems found, in the order of the settings
+	Diagnostics []Diagnostic
+}
+
+// Valid returns true if there are no error diagnostics
+func (r *Result) Valid() bool {
+	return len(r.Errors()) == 0
+}
+
+// Errors returns the diagnostics with SeverityError
+func (r *Result) Errors() []Diagnostic {
+	return r.filter(SeverityError)
+}
+
+// Warnings returns the diagnostics with SeverityWarning
+func (r *Result) Warnings() []Diagnostic {
+	return r.filter(SeverityWarning)
+}
+
+func (r *Result) filter(severity Severity) []Diagnostic {
+	var diags []Diagnostic
+	for _, d := range r.Diagnostics {
+		if d.Severity == severity {
+			diags = append(diags, d)
+		}
+	}
+	return diags
+}
@@ -0,0 +1,82 @@
+package lint
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/conventionalcommit/parser"
+)
+
+func TestLinterParseError(t *testing.T) {
+	l := New(nil, Conventional()...)
+
+	r := l.Lint("feat add x")
+	if r.Commit != nil {
+		t.Error("expected no commit for invalid message")
+	}
+	if r.Valid() || len(r.Diagnostics) != 1 || r.Diagnostics[0].Rule != ParseRule {
+		t.Errorf("expected a single parse diagnostic, got %v", r.Diagnostics)
+	}
+}
+
+func TestLinterSeverities(t *testing.T) {
+	l := New(parser.New())
+	l.Add(SeverityError, &Enum{Part: PartType, Values: []string{"feat"}})
+	l.Add(SeverityWarning, &MaxLength{Part: PartHeader, Max: 10})
+	l.Add(SeverityOff, &Empty{Part: PartScope, When: Never})
+
+	r := l.Lint("fix: add something")
+
+	if len(r.Errors()) != 1 || r.Errors()[0].Rule != "type-enum" {
+		t.Errorf("expected type-enum error, got %v", r.Errors())
+	}
+	if len(r.Warnings()) != 1 || r.Warnings()[0].Rule != "header-max-length" {
+		t.Errorf("expected header-max-length warning, got %v", r.Warnings())
+	}
+	if r.Valid() {
+		t.Error("result with errors is valid")
+	}
+
+	r = l.Lint("feat: x")
+	if !r.Valid() || len(r.Diagnostics) != 0 {
+		t.Errorf("expected no diagnostics, got %v", r.Diagnostics)
+	}
+}
+
+func TestConventional(t *testing.T) {
+	l := New(nil, Conventional()...)
+
+	var cases = map[string][]string{
+		"feat(api): add x":                      nil,
+		"feat(api): add x.":                     {"subject-full-stop"},
+		"Feat: add x":                           {"type-case", "type-enum"},
+		"feature: add x":                        {"type-enum"},
+		"feat: Add x":                           {"subject-case"},
+		"feat: add HTTP support":                nil,
+		"feat: \n\nbody":                        {"header-trim", "subject-empty"},
+		"feat: add x \n\nbody":                  {"header-trim"},
+		"feat: add x\n\nbody\n\nRefs: #1":       nil,
+		"docs: " + strings.Repeat("x", 100):     {"header-max-length"},
+		"fix: y\n\n" + strings.Repeat("x", 101): {"body-max-line-length"},
+	}
+
+	for msg, expected := range cases {
+		r := l.Lint(msg)
+
+		var rules []string
+		for _, d := range r.Diagnostics {
+			rules = append(rules, d.Rule)
+		}
+
+		if len(rules) != len(expected) {
+			t.Errorf("Lint(%q) = %v, expected %v", msg, rules, expected)
+			continue
+		}
+		for i := range rules {
+			if rules[i] != expected[i] {
+				t.Errorf("Lint(%q) = %v, expected %v", msg, rules, expected)
+				break
+			}
+		}
+	}
+}
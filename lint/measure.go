@@ -0,0 +1,37 @@
+package lint
+
+import (
+	"regexp"
+	"unicode/utf8"
+
+	"github.com/conventionalcommit/parser/width"
+)
+
+// Measure is how length rules measure text
+type Measure int
+
+// Measures of length rules
+const (
+	// MeasureWidth counts display columns, wide characters like CJK and emoji count twice
+	// and tabs advance to the next stop of width.TabWidth
+	MeasureWidth Measure = iota
+	// MeasureGraphemes counts user-perceived characters
+	MeasureGraphemes
+	// MeasureRunes counts code points
+	MeasureRunes
+)
+
+// urlPattern matches URLs with a scheme, like https://example.com/a
+var urlPattern = regexp.MustCompile(`[a-zA-Z][a-zA-Z0-9+.-]*://\S+`)
+
+// length returns the length of text
+func (m Measure) length(text string) int {
+	switch m {
+	case MeasureGraphemes:
+		return width.GraphemeCount(text)
+	case MeasureRunes:
+		return utf8.RuneCountInString(text)
+	default:
+		return width.String(text)
+	}
+}
@@ -0,0 +1,85 @@
+package lint
+
+import (
+	"fmt"
+
+	"github.com/conventionalcommit/parser"
+)
+
+// Part is a part of a commit message that rules apply to
+type Part int
+
+const (
+	// PartType is the type of the header
+	PartType Part = iota
+	// PartScope is the scope of the header
+	PartScope
+	// PartSubject is the description of the header
+	PartSubject
+	// PartHeader is the whole header
+	PartHeader
+	// PartBody is the body
+	PartBody
+	// PartFooter is the footer with all notes
+	PartFooter
+)
+
+// String returns the name of the part as used in rule names
+func (p Part) String() string {
+	switch p {
+	case PartType:
+		return "type"
+	case PartScope:
+		return "scope"
+	case PartSubject:
+		return "subject"
+	case PartHeader:
+		return "header"
+	case PartBody:
+		return "body"
+	case PartFooter:
+		return "footer"
+	default:
+		return fmt.Sprintf("Part(%d)", int(p))
+	}
+}
+
+// text returns the text of the part in the commit
+func (p Part) text(c *parser.Commit) string {
+	switch p {
+	case PartType:
+		return c.Type()
+	case PartScope:
+		return c.Scope()
+	case PartSubject:
+		return c.Description()
+	case PartHeader:
+		return c.Header()
+	case PartBody:
+		return c.Body()
+	case PartFooter:
+		return c.Footer()
+	default:
+		return ""
+	}
+}
+
+// span returns the position of the part in the commit
+func (p Part) span(c *parser.Commit) parser.Span {
+	switch p {
+	case PartType:
+		return c.TypeSpan()
+	case PartScope:
+		return c.ScopeSpan()
+	case PartSubject:
+		return c.DescriptionSpan()
+	case PartHeader:
+		return c.HeaderSpan()
+	case PartBody:
+		return c.BodySpan()
+	case PartFooter:
+		return c.FooterSpan()
+	default:
+		return parser.Span{}
+	}
+}
@@ -0,0 +1,39 @@
+package lint
+
+import (
+	"sort"
+
+	"github.com/conventionalcommit/parser"
+)
+
+// ConventionalTypes are the types allowed by the conventional preset, the
+// names of parser.DefaultTypeRegistry in sorted order
+var ConventionalTypes = conventionalTypes()
+
+func conventionalTypes() []string {
+	var types []string
+	for _, t := range parser.DefaultTypeRegistry().Types() {
+		types = append(types, t.Name)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// Conventional returns the settings of the conventional preset, the
+// equivalent of @commitlint/config-conventional
+func Conventional() []Setting {
+	return []Setting{
+		{SeverityWarning, &LeadingBlank{Part: PartBody, When: Always}},
+		{SeverityError, &MaxLineLength{Part: PartBody, Max: 100}},
+		{SeverityWarning, &LeadingBlank{Part: PartFooter, When: Always}},
+		{SeverityError, &MaxLineLength{Part: PartFooter, Max: 100}},
+		{SeverityError, &MaxLength{Part: PartHeader, Max: 100}},
+		{SeverityError, &HeaderTrim{}},
+		{SeverityError, &Case{Part: PartSubject, When: Never, Cases: []string{"sentence-case", "start-case", "pascal-case", "upper-case"}}},
+		{SeverityError, &Empty{Part: PartSubject, When: Never}},
+		{SeverityError, &FullStop{Part: PartSubject, When: Never, Value: "."}},
+		{SeverityError, &Case{Part: PartType, When: Always, Cases: []string{"lower-case"}}},
+		{SeverityError, &Empty{Part: PartType, When: Never}},
+		{SeverityError, &Enum{Part: PartType, When: Always, Values: ConventionalTypes}},
+	}
+}
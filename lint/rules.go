@@ -0,0 +1,805 @@
+package lint
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/conventionalcommit/parser"
+	"github.com/conventionalcommit/parser/casing"
+	"github.com/conventionalcommit/parser/forge"
+	"github.com/conventionalcommit/parser/scope"
+	"github.com/conventionalcommit/parser/wrap"
+)
+
+// Patterns of the delimiters of a list of scopes and of the segments of a
+// path scope
+const (
+	scopeListPattern = `, ?`
+	scopePathPattern = `/|\\`
+)
+
+var (
+	// scopeListDelimiter separates a list of scopes, like "api,ui"
+	scopeListDelimiter = regexp.MustCompile(scopeListPattern)
+	// scopePathDelimiter separates the segments of a path scope, like "api/ui"
+	scopePathDelimiter = regexp.MustCompile(scopePathPattern)
+	// scopeDelimiters separate multiple scopes of either kind
+	scopeDelimiters = regexp.MustCompile(scopeListPattern + "|" + scopePathPattern)
+)
+
+// breakingTokenLine matches lines starting with a breaking change token in
+// any spelling, like "Breaking change:" or "BREAKING CHANGES :"
+var breakingTokenLine = regexp.MustCompile(`(?im)^(breaking[ _-]?changes?)[ \t]*:[ \t]*`)
+
+// Enum requires the part to be one of Values, or none of them with Never.
+// Empty parts are ignored. Multiple scopes are checked individually.
+//
+// Name: type-enum, scope-enum
+type Enum struct {
+	Part   Part
+	When   Condition
+	Values []string
+}
+
+// Name returns the name of the rule
+func (r *Enum) Name() string {
+	return r.Part.String() + "-enum"
+}
+
+// Check checks the rule
+func (r *Enum) Check(c *parser.Commit) []Problem {
+	text := r.Part.text(c)
+	if text == "" {
+		return nil
+	}
+
+	values := []string{text}
+	if r.Part == PartScope {
+		values = scopeDelimiters.Split(text, -1)
+	}
+
+	for _, v := range values {
+		if contains(r.Values, v) == (r.When == Always) {
+			continue
+		}
+
+		verb := "must"
+		if r.When == Never {
+			verb = "must not"
+		}
+		return []Problem{{
+			Message: fmt.Sprintf("%s %s be one of [%s]", r.Part, verb, strings.Join(r.Values, ", ")),
+			Span:    r.Part.span(c),
+		}}
+	}
+
+	return nil
+}
+
+// Empty requires the part to be empty, or not to be empty with Never
+//
+// Name: type-empty, scope-empty, subject-empty, body-empty, footer-empty
+type Empty struct {
+	Part Part
+	When Condition
+}
+
+// Name returns the name of the rule
+func (r *Empty) Name() string {
+	return r.Part.String() + "-empty"
+}
+
+// Check checks the rule
+func (r *Empty) Check(c *parser.Commit) []Problem {
+	isEmpty := r.Part.text(c) == ""
+
+	if r.When == Never && isEmpty {
+		return []Problem{{
+			Message: fmt.Sprintf("%s may not be empty", r.Part),
+			Span:    r.Part.span(c),
+		}}
+	}
+
+	if r.When == Always && !isEmpty {
+		return []Problem{{
+			Message: fmt.Sprintf("%s must be empty", r.Part),
+			Span:    r.Part.span(c),
+		}}
+	}
+
+	return nil
+}
+
+// MaxLength limits the length of the part, measured in display width by
+// default. Empty parts are ignored.
+//
+// Name: type-max-length, scope-max-length, subject-max-length, header-max-length, body-max-length, footer-max-length
+type MaxLength struct {
+	Part    Part
+	Max     int
+	Measure Measure
+}
+
+// Name returns the name of the rule
+func (r *MaxLength) Name() string {
+	return r.Part.String() + "-max-length"
+}
+
+// Check checks the rule
+func (r *MaxLength) Check(c *parser.Commit) []Problem {
+	length := r.Measure.length(r.Part.text(c))
+	if length <= r.Max {
+		return nil
+	}
+
+	return []Problem{{
+		Message: fmt.Sprintf("%s must not be longer than %d characters, current length is %d", r.Part, r.Max, length),
+		Span:    r.Part.span(c),
+	}}
+}
+
+// MinLength requires a minimum length of the part, measured in display width
+// by default. Empty parts are ignored.
+//
+// Name: type-min-length, scope-min-length, subject-min-length, header-min-length, body-min-length, footer-min-length
+type MinLength struct {
+	Part    Part
+	Min     int
+	Measure Measure
+}
+
+// Name returns the name of the rule
+func (r *MinLength) Name() string {
+	return r.Part.String() + "-min-length"
+}
+
+// Check checks the rule
+func (r *MinLength) Check(c *parser.Commit) []Problem {
+	text := r.Part.text(c)
+	length := r.Measure.length(text)
+	if text == "" || length >= r.Min {
+		return nil
+	}
+
+	return []Problem{{
+		Message: fmt.Sprintf("%s must not be shorter than %d characters, current length is %d", r.Part, r.Min, length),
+		Span:    r.Part.span(c),
+	}}
+}
+
+// MaxLineLength limits the length of every line of the part, measured in
+// display width by default. Lines containing a URL are exempt with
+// IgnoreURLs, and lines of footer notes with one of IgnoreTrailers tokens,
+// like "Signed-off-by". The fix reflows the part with the wrap package.
+//
+// Name: body-max-line-length, footer-max-line-length
+type MaxLineLength struct {
+	Part           Part
+	Max            int
+	Measure        Measure
+	IgnoreURLs     bool
+	IgnoreTrailers []string
+}
+
+// Name returns the name of the rule
+func (r *MaxLineLength) Name() string {
+	return r.Part.String() + "-max-line-length"
+}
+
+// Check checks the rule
+func (r *MaxLineLength) Check(c *parser.Commit) []Problem {
+	var problems []Problem
+
+	start := r.Part.span(c).Start
+	for _, line := range strings.Split(r.Part.text(c), "\n") {
+		span := parser.Span{Start: start, End: start + len(line)}
+		start += len(line) + 1
+
+		if r.isExempt(c, line, span) {
+			continue
+		}
+
+		if length := r.Measure.length(line); length > r.Max {
+			problems = append(problems, Problem{
+				Message: fmt.Sprintf("%s's lines must not be longer than %d characters, current length is %d", r.Part, r.Max, length),
+				Span:    span,
+			})
+		}
+	}
+
+	// a single fix reflows the whole part
+	if len(problems) > 0 {
+		problems[0].Edits = r.fix(c)
+	}
+
+	return problems
+}
+
+func (r *MaxLineLength) fix(c *parser.Commit) []parser.Edit {
+	text := r.Part.text(c)
+
+	wrapped := text
+	switch r.Part {
+	case PartBody:
+		wrapped = wrap.Body(text, r.Max, c.WrapOptions()...)
+	case PartFooter:
+		wrapped = wrap.Footer(text, r.Max, c.WrapOptions()...)
+	}
+
+	if wrapped == text {
+		return nil
+	}
+	return []parser.Edit{{Span: r.Part.span(c), Text: wrapped}}
+}
+
+func (r *MaxLineLength) isExempt(c *parser.Commit, line string, span parser.Span) bool {
+	if r.IgnoreURLs && urlPattern.MatchString(line) {
+		return true
+	}
+
+	for _, n := range c.Notes() {
+		if contains(r.IgnoreTrailers, n.Token()) && span.Start >= n.Span().Start && span.Start < n.Span().End {
+			return true
+		}
+	}
+	return false
+}
+
+// FullStop requires the part to end with Value, or not to end with it with
+// Never. Value defaults to ".". Empty parts are ignored.
+//
+// Name: subject-full-stop, header-full-stop, body-full-stop
+type FullStop struct {
+	Part  Part
+	When  Condition
+	Value string
+}
+
+// Name returns the name of the rule
+func (r *FullStop) Name() string {
+	return r.Part.String() + "-full-stop"
+}
+
+// Check checks the rule
+func (r *FullStop) Check(c *parser.Commit) []Problem {
+	text := r.Part.text(c)
+	if text == "" {
+		return nil
+	}
+
+	value := r.value()
+	hasStop := strings.HasSuffix(text, value)
+
+	span := r.Part.span(c)
+
+	if r.When == Never && hasStop {
+		stop := parser.Span{Start: span.End - len(value), End: span.End}
+		return []Problem{{
+			Message: fmt.Sprintf("%s may not end with full stop", r.Part),
+			Span:    stop,
+			Edits:   []parser.Edit{{Span: stop}},
+		}}
+	}
+
+	if r.When == Always && !hasStop {
+		return []Problem{{
+			Message: fmt.Sprintf("%s must end with full stop", r.Part),
+			Span:    span,
+			Edits:   []parser.Edit{{Span: parser.Span{Start: span.End, End: span.End}, Text: value}},
+		}}
+	}
+
+	return nil
+}
+
+func (r *FullStop) value() string {
+	if r.Value == "" {
+		return "."
+	}
+	return r.Value
+}
+
+// LeadingBlank requires a blank line before the part, or no blank line with
+// Never. Missing parts are ignored.
+//
+// Name: body-leading-blank, footer-leading-blank
+type LeadingBlank struct {
+	Part Part
+	When Condition
+}
+
+// Name returns the name of the rule
+func (r *LeadingBlank) Name() string {
+	return r.Part.String() + "-leading-blank"
+}
+
+// Check checks the rule
+func (r *LeadingBlank) Check(c *parser.Commit) []Problem {
+	span := r.Part.span(c)
+	if span.Len() == 0 {
+		return nil
+	}
+
+	before := strings.TrimRight(c.Message()[:span.Start], " \t")
+	hasBlank := strings.HasSuffix(before, "\n\n")
+
+	if r.When == Always && !hasBlank {
+		return []Problem{{
+			Message: fmt.Sprintf("%s must have leading blank line", r.Part),
+			Span:    parser.Span{Start: span.Start, End: span.Start},
+			Edits:   []parser.Edit{{Span: parser.Span{Start: span.Start, End: span.Start}, Text: "\n"}},
+		}}
+	}
+
+	if r.When == Never && hasBlank {
+		return []Problem{{
+			Message: fmt.Sprintf("%s may not have leading blank line", r.Part),
+			Span:    parser.Span{Start: span.Start, End: span.Start},
+		}}
+	}
+
+	return nil
+}
+
+// Case requires the part to be in one of Cases, or in none of them with
+// Never. Cases are commitlint names like "lower-case" or "kebab-case", see
+// the casing package. Empty parts are ignored. Multiple scopes are checked
+// individually.
+//
+// With Always, the fix converts the part to the first case. With Never, it
+// lower-cases the first letter, or the whole part if that is not enough.
+//
+// Name: type-case, scope-case, subject-case, header-case, body-case
+type Case struct {
+	Part  Part
+	When  Condition
+	Cases []string
+}
+
+// Name returns the name of the rule
+func (r *Case) Name() string {
+	return r.Part.String() + "-case"
+}
+
+// Check checks the rule
+func (r *Case) Check(c *parser.Commit) []Problem {
+	text := r.Part.text(c)
+	if text == "" {
+		return nil
+	}
+
+	span := r.Part.span(c)
+	segments := []parser.Span{{Start: 0, End: len(text)}}
+	if r.Part == PartScope {
+		segments = splitSpans(text, scopeDelimiters)
+	}
+
+	var edits []parser.Edit
+	valid := true
+	for _, seg := range segments {
+		value := text[seg.Start:seg.End]
+		if r.matches(value) == (r.When == Always) {
+			continue
+		}
+		valid = false
+
+		if fixed, ok := r.fix(value); ok {
+			edits = append(edits, parser.Edit{
+				Span: parser.Span{Start: span.Start + seg.Start, End: span.Start + seg.End},
+				Text: fixed,
+			})
+		}
+	}
+
+	if valid {
+		return nil
+	}
+
+	verb := "must"
+	if r.When == Never {
+		verb = "must not"
+	}
+	return []Problem{{
+		Message: fmt.Sprintf("%s %s be %s", r.Part, verb, strings.Join(r.Cases, ", ")),
+		Span:    span,
+		Edits:   edits,
+	}}
+}
+
+func (r *Case) matches(text string) bool {
+	for _, name := range r.Cases {
+		if c, ok := casing.ByName(name); ok && c.Is(text) {
+			return true
+		}
+	}
+	return false
+}
+
+// fix returns text converted to satisfy the rule
+func (r *Case) fix(text string) (string, bool) {
+	if r.When == Always {
+		if len(r.Cases) == 0 {
+			return "", false
+		}
+		c, ok := casing.ByName(r.Cases[0])
+		return casing.Convert(text, c), ok
+	}
+
+	for _, fixed := range []string{lowerFirst(text), strings.ToLower(text)} {
+		if !r.matches(fixed) {
+			return fixed, true
+		}
+	}
+	return "", false
+}
+
+func isKnownCase(name string) bool {
+	_, ok := casing.ByName(name)
+	return ok
+}
+
+// lowerFirst lower-cases the first letter, unless it starts an acronym like "API"
+func lowerFirst(text string) string {
+	words := casing.Words(text)
+	if len(words) > 0 && strings.HasPrefix(text, words[0]) && strings.ToUpper(words[0]) == words[0] && utf8.RuneCountInString(words[0]) > 1 {
+		return text
+	}
+
+	r, size := utf8.DecodeRuneInString(text)
+	return string(unicode.ToLower(r)) + text[size:]
+}
+
+// splitSpans returns the spans of text between matches of sep
+func splitSpans(text string, sep *regexp.Regexp) []parser.Span {
+	var spans []parser.Span
+
+	start := 0
+	for _, m := range sep.FindAllStringIndex(text, -1) {
+		spans = append(spans, parser.Span{Start: start, End: m[0]})
+		start = m[1]
+	}
+	return append(spans, parser.Span{Start: start, End: len(text)})
+}
+
+// ExclamationMark requires a "!" before the colon of the header, or none
+// with Never.
+//
+// Name: subject-exclamation-mark
+type ExclamationMark struct {
+	When Condition
+}
+
+// Name returns the name of the rule
+func (r *ExclamationMark) Name() string {
+	return "subject-exclamation-mark"
+}
+
+// Check checks the rule
+func (r *ExclamationMark) Check(c *parser.Commit) []Problem {
+	prefix := c.Message()[:c.DescriptionSpan().Start]
+	pos := strings.LastIndex(prefix, "!:")
+	hasMark := pos >= 0
+
+	if r.When == Never && hasMark {
+		return []Problem{{
+			Message: "subject must not have an exclamation mark before the colon",
+			Span:    parser.Span{Start: pos, End: pos + 1},
+		}}
+	}
+
+	if r.When == Always && !hasMark {
+		return []Problem{{
+			Message: "subject must have an exclamation mark before the colon",
+			Span:    c.HeaderSpan(),
+		}}
+	}
+
+	return nil
+}
+
+// TrailerExists requires a footer note with the token Value, like
+// "Signed-off-by", or none with Never. A trailing separator in Value is ignored.
+//
+// Name: trailer-exists
+type TrailerExists struct {
+	When  Condition
+	Value string
+}
+
+// Name returns the name of the rule
+func (r *TrailerExists) Name() string {
+	return "trailer-exists"
+}
+
+// Check checks the rule
+func (r *TrailerExists) Check(c *parser.Commit) []Problem {
+	token := strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(r.Value), ":"))
+
+	var found *parser.Note
+	for i, n := range c.Notes() {
+		if n.Token() == token {
+			found = &c.Notes()[i]
+			break
+		}
+	}
+
+	if r.When == Always && found == nil {
+		return []Problem{{
+			Message: fmt.Sprintf("message must have `%s` trailer", token),
+			Span:    endSpan(c),
+		}}
+	}
+
+	if r.When == Never && found != nil {
+		return []Problem{{
+			Message: fmt.Sprintf("message must not have `%s` trailer", token),
+			Span:    found.Span(),
+		}}
+	}
+
+	return nil
+}
+
+// SignedOffBy requires the last line of the message to start with Value,
+// or not to with Never. Value defaults to "Signed-off-by:".
+//
+// Name: signed-off-by
+type SignedOffBy struct {
+	When  Condition
+	Value string
+}
+
+// Name returns the name of the rule
+func (r *SignedOffBy) Name() string {
+	return "signed-off-by"
+}
+
+// Check checks the rule
+func (r *SignedOffBy) Check(c *parser.Commit) []Problem {
+	value := r.Value
+	if value == "" {
+		value = "Signed-off-by:"
+	}
+
+	msg := c.Message()
+	lastLine := msg[strings.LastIndex(msg, "\n")+1:]
+	signed := strings.HasPrefix(lastLine, value)
+
+	if r.When == Always && !signed {
+		return []Problem{{
+			Message: fmt.Sprintf("message must be signed off with `%s`", value),
+			Span:    endSpan(c),
+		}}
+	}
+
+	if r.When == Never && signed {
+		return []Problem{{
+			Message: fmt.Sprintf("message must not be signed off with `%s`", value),
+			Span:    parser.Span{Start: len(msg) - len(lastLine), End: len(msg)},
+		}}
+	}
+
+	return nil
+}
+
+// HeaderTrim requires the header line to have no leading or trailing
+// whitespace. Messages are trimmed before they are parsed, so only
+// whitespace at the end of the header of a message with a body or footers
+// is found. The fix removes the whitespace.
+//
+// Name: header-trim
+type HeaderTrim struct{}
+
+// Name returns the name of the rule
+func (r *HeaderTrim) Name() string {
+	return "header-trim"
+}
+
+// Check checks the rule
+func (r *HeaderTrim) Check(c *parser.Commit) []Problem {
+	msg := c.Message()
+	start := c.HeaderSpan().Start
+	end := len(msg)
+	if i := strings.IndexByte(msg[start:], '\n'); i >= 0 {
+		end = start + i
+	}
+
+	line := msg[start:end]
+	leading := len(line) - len(strings.TrimLeftFunc(line, unicode.IsSpace))
+	trailing := len(line) - len(strings.TrimRightFunc(line, unicode.IsSpace))
+	if leading == 0 && trailing == 0 {
+		return nil
+	}
+
+	var edits []parser.Edit
+	if leading > 0 {
+		edits = append(edits, parser.Edit{Span: parser.Span{Start: start, End: start + leading}})
+	}
+	if trailing > 0 {
+		edits = append(edits, parser.Edit{Span: parser.Span{Start: end - trailing, End: end}})
+	}
+	return []Problem{{
+		Message: "header must not be surrounded by whitespace",
+		Span:    parser.Span{Start: start, End: end},
+		Edits:   edits,
+	}}
+}
+
+// ReferencesEmpty requires the message to reference no issue, or at least
+// one with Never. References are "#12" or "owner/repo#12", as found by
+// forge.Links.Find.
+//
+// Name: references-empty
+type ReferencesEmpty struct {
+	When Condition
+}
+
+// Name returns the name of the rule
+func (r *ReferencesEmpty) Name() string {
+	return "references-empty"
+}
+
+// Check checks the rule
+func (r *ReferencesEmpty) Check(c *parser.Commit) []Problem {
+	var issues []forge.Reference
+	for _, ref := range (&forge.Links{}).References(c) {
+		if ref.Kind == forge.ReferenceIssue {
+			issues = append(issues, ref)
+		}
+	}
+
+	if r.When == Never && len(issues) == 0 {
+		return []Problem{{
+			Message: "references may not be empty",
+			Span:    endSpan(c),
+		}}
+	}
+
+	if r.When == Always && len(issues) > 0 {
+		return []Problem{{
+			Message: "references must be empty",
+			Span:    parser.Span{Start: issues[0].Start, End: issues[0].End},
+		}}
+	}
+
+	return nil
+}
+
+// KnownScope requires every scope to be one of Scopes, usually discovered
+// from the repository layout with the scope package. Multiple scopes
+// separated by commas are checked individually, paths like "api/v1" as a
+// whole or by their segments. Problems suggest the closest known scope, the
+// fix replaces the scope if there is a single suggestion. Empty scopes are
+// ignored.
+//
+// Name: scope-known
+type KnownScope struct {
+	Scopes []string
+}
+
+// Name returns the name of the rule
+func (r *KnownScope) Name() string {
+	return "scope-known"
+}
+
+// Check checks the rule
+func (r *KnownScope) Check(c *parser.Commit) []Problem {
+	text := c.Scope()
+	if text == "" {
+		return nil
+	}
+
+	var problems []Problem
+
+	start := c.ScopeSpan().Start
+	for _, seg := range splitSpans(text, scopeListDelimiter) {
+		value := text[seg.Start:seg.End]
+		if r.isKnown(value) {
+			continue
+		}
+
+		span := parser.Span{Start: start + seg.Start, End: start + seg.End}
+		p := Problem{
+			Message: fmt.Sprintf("scope %q is unknown", value),
+			Span:    span,
+		}
+
+		suggestions := scope.Suggest(value, r.Scopes)
+		if len(suggestions) > 0 {
+			p.Message += fmt.Sprintf(", did you mean %q?", strings.Join(suggestions, `" or "`))
+		}
+		if len(suggestions) == 1 {
+			p.Edits = []parser.Edit{{Span: span, Text: suggestions[0]}}
+		}
+
+		problems = append(problems, p)
+	}
+
+	return problems
+}
+
+func (r *KnownScope) isKnown(value string) bool {
+	if contains(r.Scopes, value) {
+		return true
+	}
+
+	parts := scopePathDelimiter.Split(value, -1)
+	if len(parts) < 2 {
+		return false
+	}
+	for _, part := range parts {
+		if !contains(r.Scopes, part) {
+			return false
+		}
+	}
+	return true
+}
+
+// BreakingChangeToken requires breaking change footers to use the token
+// "BREAKING CHANGE" or "BREAKING-CHANGE" followed by ": ", or only Token if
+// set. Lines after the header starting with another spelling, like
+// "Breaking change:", are reported and fixed to Token, which defaults to
+// "BREAKING CHANGE".
+//
+// Name: breaking-change-token
+type BreakingChangeToken struct {
+	Token string
+}
+
+// Name returns the name of the rule
+func (r *BreakingChangeToken) Name() string {
+	return "breaking-change-token"
+}
+
+// Check checks the rule
+func (r *BreakingChangeToken) Check(c *parser.Commit) []Problem {
+	token := r.Token
+	if token == "" {
+		token = "BREAKING CHANGE"
+	}
+
+	var problems []Problem
+
+	offset := c.HeaderSpan().End
+	for _, m := range breakingTokenLine.FindAllStringSubmatchIndex(c.Message()[offset:], -1) {
+		span := parser.Span{Start: offset + m[0], End: offset + m[1]}
+		text := c.Message()[span.Start:span.End]
+		tok := c.Message()[offset+m[2] : offset+m[3]]
+
+		if r.isAllowed(tok) && text == tok+": " {
+			continue
+		}
+
+		problems = append(problems, Problem{
+			Message: fmt.Sprintf("breaking change must be introduced with `%s: `", token),
+			Span:    parser.Span{Start: span.Start, End: offset + m[3]},
+			Edits:   []parser.Edit{{Span: span, Text: token + ": "}},
+		})
+	}
+
+	return problems
+}
+
+func (r *BreakingChangeToken) isAllowed(tok string) bool {
+	if r.Token != "" {
+		return tok == r.Token
+	}
+	return tok == "BREAKING CHANGE" || tok == "BREAKING-CHANGE"
+}
+
+// endSpan returns an empty span at the end of the message
+func endSpan(c *parser.Commit) parser.Span {
+	return parser.Span{Start: len(c.Message()), End: len(c.Message())}
+}
+
+func contains(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
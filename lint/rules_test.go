@@ -0,0 +1,132 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/conventionalcommit/parser"
+)
+
+type ruleCase struct {
+	rule    Rule
+	message string
+	// spans are the texts of the expected problem spans
+	spans []string
+}
+
+func TestRules(t *testing.T) {
+	var cases = []ruleCase{
+		{&Enum{Part: PartType, Values: []string{"feat", "fix"}}, "feat: x", nil},
+		{&Enum{Part: PartType, Values: []string{"feat", "fix"}}, "docs: x", []string{"docs"}},
+		{&Enum{Part: PartType, When: Never, Values: []string{"wip"}}, "wip: x", []string{"wip"}},
+		{&Enum{Part: PartScope, Values: []string{"api", "ui"}}, "feat(api,ui): x", nil},
+		{&Enum{Part: PartScope, Values: []string{"api", "ui"}}, "feat(api/db): x", []string{"api/db"}},
+		{&Enum{Part: PartScope, Values: []string{"api"}}, "feat: x", nil},
+
+		{&Empty{Part: PartScope, When: Never}, "feat: x", []string{""}},
+		{&Empty{Part: PartScope, When: Never}, "feat(api): x", nil},
+		{&Empty{Part: PartBody, When: Always}, "feat: x\n\nbody", []string{"body"}},
+
+		{&MaxLength{Part: PartHeader, Max: 10}, "feat: 12345", []string{"feat: 12345"}},
+		{&MaxLength{Part: PartHeader, Max: 11}, "feat: 12345", nil},
+		{&MaxLength{Part: PartSubject, Max: 3}, "feat: äöü", nil},
+		{&MaxLength{Part: PartSubject, Max: 6}, "feat: 修复登录", []string{"修复登录"}},
+		{&MaxLength{Part: PartSubject, Max: 4, Measure: MeasureRunes}, "feat: 修复登录", nil},
+		{&MaxLength{Part: PartSubject, Max: 2}, "feat: 👩\u200d💻", nil},
+		{&MaxLength{Part: PartSubject, Max: 1, Measure: MeasureGraphemes}, "feat: 👩\u200d💻", nil},
+		{&MaxLength{Part: PartSubject, Max: 1, Measure: MeasureRunes}, "feat: 👩\u200d💻", []string{"👩\u200d💻"}},
+		{&MinLength{Part: PartSubject, Min: 3}, "feat: ab", []string{"ab"}},
+		{&MinLength{Part: PartSubject, Min: 3}, "feat: 修复", nil},
+		{&MinLength{Part: PartBody, Min: 3}, "feat: ab", nil},
+
+		{&MaxLineLength{Part: PartBody, Max: 3}, "feat: x\n\nabc\nabcd\n\nab", []string{"abcd"}},
+		{&MaxLineLength{Part: PartFooter, Max: 8}, "feat: x\n\nRefs: #1\nAcked-by: Z", []string{"Acked-by: Z"}},
+		{&MaxLineLength{Part: PartBody, Max: 10}, "feat: x\n\nsee https://example.com/issues/1", []string{"see https://example.com/issues/1"}},
+		{&MaxLineLength{Part: PartBody, Max: 10, IgnoreURLs: true}, "feat: x\n\nsee https://example.com/issues/1", nil},
+		{&MaxLineLength{Part: PartFooter, Max: 12, IgnoreTrailers: []string{"Signed-off-by"}}, "feat: x\n\nRefs: #1\nSigned-off-by: Jane Doe <jane@example.com>", nil},
+		{&MaxLineLength{Part: PartFooter, Max: 12, IgnoreTrailers: []string{"Signed-off-by"}}, "feat: x\n\nAcked-by: Jane Doe <jane@example.com>", []string{"Acked-by: Jane Doe <jane@example.com>"}},
+
+		{&FullStop{Part: PartSubject, When: Never}, "feat: add x.", []string{"."}},
+		{&FullStop{Part: PartSubject, When: Never}, "feat: add x", nil},
+		{&FullStop{Part: PartSubject, When: Always, Value: "!"}, "feat: add x", []string{"add x"}},
+		{&FullStop{Part: PartHeader, When: Never, Value: "..."}, "feat: add x...", []string{"..."}},
+
+		{&LeadingBlank{Part: PartBody, When: Always}, "feat: x\n\nbody", nil},
+		{&LeadingBlank{Part: PartBody, When: Always}, "feat: x", nil},
+		{&LeadingBlank{Part: PartFooter, When: Never}, "feat: x\n\nRefs: #1", []string{""}},
+
+		{&Case{Part: PartType, Cases: []string{"lower-case"}}, "Feat: x", []string{"Feat"}},
+		{&Case{Part: PartType, Cases: []string{"lower-case"}}, "feat: x", nil},
+		{&Case{Part: PartScope, When: Never, Cases: []string{"upper-case"}}, "feat(API): x", []string{"API"}},
+		{&Case{Part: PartScope, Cases: []string{"kebab-case"}}, "feat(user-api,ui): x", nil},
+		{&Case{Part: PartScope, Cases: []string{"kebab-case"}}, "feat(user-api,UserUI): x", []string{"user-api,UserUI"}},
+		{&Case{Part: PartSubject, Cases: []string{"camel-case", "pascal-case"}}, "feat: UserAPI", nil},
+		{&Case{Part: PartSubject, When: Never, Cases: []string{"sentence-case"}}, "feat: Add x", []string{"Add x"}},
+
+		{&ExclamationMark{When: Never}, "feat(api)!: x", []string{"!"}},
+		{&ExclamationMark{When: Never}, "feat(api): x!: y", nil},
+		{&ExclamationMark{When: Always}, "feat: x", []string{"feat: x"}},
+
+		{&TrailerExists{Value: "Signed-off-by:"}, "feat: x\n\nSigned-off-by: Jane", nil},
+		{&TrailerExists{Value: "Signed-off-by:"}, "feat: x\n\nRefs: #1", []string{""}},
+		{&TrailerExists{When: Never, Value: "Change-Id"}, "feat: x\n\nChange-Id: I123", []string{"Change-Id: I123"}},
+
+		{&SignedOffBy{}, "feat: x\n\nRefs: #1\nSigned-off-by: Jane", nil},
+		{&SignedOffBy{}, "feat: x\n\nSigned-off-by: Jane\nRefs: #1", []string{""}},
+		{&SignedOffBy{When: Never}, "feat: x\n\nSigned-off-by: Jane", []string{"Signed-off-by: Jane"}},
+
+		{&HeaderTrim{}, "feat: x\n\nbody", nil},
+		{&HeaderTrim{}, "feat: x \t\n\nbody", []string{"feat: x \t"}},
+
+		{&ReferencesEmpty{}, "feat: x\n\nsee abc", nil},
+		{&ReferencesEmpty{}, "feat: x\n\nRefs: owner/repo#12", []string{"owner/repo#12"}},
+		{&ReferencesEmpty{When: Never}, "fix: x (#3)", nil},
+		{&ReferencesEmpty{When: Never}, "feat: x\n\nby @octocat", []string{""}},
+
+		{&ImperativeMood{}, "feat: add x", nil},
+		{&ImperativeMood{}, "feat: added x", []string{"added"}},
+		{&ImperativeMood{}, "feat: Adds x", []string{"Adds"}},
+		{&ImperativeMood{}, "fix: wrote y", []string{"wrote"}},
+		{&ImperativeMood{}, "fix: kubernetes support", nil},
+		{&ImperativeMood{}, "fix: dockerized build", nil},
+		{&ImperativeMood{Verbs: []string{"dockerize"}}, "fix: dockerized build", []string{"dockerized"}},
+		{&ImperativeMood{Allowed: []string{"updates"}}, "chore: updates for deps", nil},
+
+		{&KnownScope{Scopes: []string{"api", "ui", "internal/cache"}}, "feat(api,ui): x", nil},
+		{&KnownScope{Scopes: []string{"api", "ui", "internal/cache"}}, "feat(internal/cache): x", nil},
+		{&KnownScope{Scopes: []string{"api", "ui"}}, "feat(api/ui): x", nil},
+		{&KnownScope{Scopes: []string{"api", "ui"}}, "feat(api, db): x", []string{"db"}},
+		{&KnownScope{Scopes: []string{"api"}}, "feat: x", nil},
+
+		{&BreakingChangeToken{}, "feat: x\n\nBREAKING-CHANGE: y", nil},
+		{&BreakingChangeToken{}, "feat: x\n\nBreaking change: y", []string{"Breaking change"}},
+		{&BreakingChangeToken{}, "feat: x\n\nbody\nBREAKING CHANGES : y", []string{"BREAKING CHANGES"}},
+		{&BreakingChangeToken{Token: "BREAKING CHANGE"}, "feat: x\n\nBREAKING-CHANGE: y", []string{"BREAKING-CHANGE"}},
+		{&BreakingChangeToken{}, "feat: breaking change: x", nil},
+	}
+
+	runRuleCases(t, cases)
+}
+
+func runRuleCases(t *testing.T, cases []ruleCase) {
+	p := parser.New()
+
+	for _, tc := range cases {
+		c, err := p.Parse(tc.message)
+		if err != nil {
+			t.Errorf("%s: Parse(%q) failed: %v", tc.rule.Name(), tc.message, err)
+			continue
+		}
+
+		problems := tc.rule.Check(c)
+		if len(problems) != len(tc.spans) {
+			t.Errorf("%s: Check(%q) = %v, expected %d problems", tc.rule.Name(), tc.message, problems, len(tc.spans))
+			continue
+		}
+
+		for i, p := range problems {
+			if actual := c.Message()[p.Span.Start:p.Span.End]; actual != tc.spans[i] {
+				t.Errorf("%s: Check(%q) span %q, expected %q", tc.rule.Name(), tc.message, actual, tc.spans[i])
+			}
+		}
+	}
+}
@@ -0,0 +1,10 @@
+// commitlint configuration
+module.exports = {
+  extends: ['@commitlint/config-conventional'],
+  rules: {
+    /* scopes of the monorepo */
+    'scope-enum': [2, 'always', ['api', 'ui']],
+    'header-max-length': [1, 'always', 72], // shorter than the preset
+    'body-leading-blank': [0],
+  },
+};
@@ -2,15 +2,63 @@
 package parser
 
 import (
+	"sort"
 	"strings"
 )
 
 // Parser represent a conventional commits parser
-type Parser struct{}
+type Parser struct {
+	mode Mode
+
+	registry           *TypeRegistry
+	rejectUnknownTypes bool
+
+	breakingTokens       []string
+	footerSeparators     []string
+	gitTrailerSeparators string
+}
+
+// Option configures a Parser
+type Option func(*Parser)
 
 // New returns a new Parser instance
-func New() *Parser {
-	return &Parser{}
+func New(opts ...Option) *Parser {
+	p := &Parser{
+		breakingTokens:   []string{breakingTokenSpace, breakingTokenHyphen},
+		footerSeparators: []string{footerSeparatorColon, footerSeparatorHash},
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	// prefer the longest separator, so ": " wins over ":"
+	sort.SliceStable(p.footerSeparators, func(i, j int) bool {
+		return len(p.footerSeparators[i]) > len(p.footerSeparators[j])
+	})
+
+	if p.rejectUnknownTypes && p.registry == nil {
+		p.registry = DefaultTypeRegistry()
+	}
+
+	return p
+}
+
+// WithBreakingChangeTokens adds footer tokens that mark a breaking change,
+// like "BREAKING". "BREAKING CHANGE" and "BREAKING-CHANGE" are always recognized.
+// Like them, the tokens must be followed by ": ", with other separators,
+// like "BREAKING CHANGE=x", the line is no footer.
+func WithBreakingChangeTokens(tokens ...string) Option {
+	return func(p *Parser) {
+		p.breakingTokens = append(p.breakingTokens, tokens...)
+	}
+}
+
+// WithFooterSeparators adds separators between footer tokens and values,
+// like "=" or ":". ": " and " #" are always recognized.
+func WithFooterSeparators(separators ...string) Option {
+	return func(p *Parser) {
+		p.footerSeparators = append(p.footerSeparators, separators...)
+	}
 }
 
 // Parse parses the conventional commit. If it fails, an error is returned.
@@ -21,17 +69,23 @@ func (p *Parser) Parse(input string) (*Commit, error) {
 
 func (p *Parser) parse(input string) (*Commit, error) {
 	lex := newLexer(input, typeState, func(error) {})
+	lex.breakingTokens = p.breakingTokens
+	lex.footerSeparators = p.footerSeparators
+	lex.gitTrailerSeparators = p.gitTrailerSeparators
 	lex.Start()
 
 	c := &Commit{
-		message: input,
+		message:              input,
+		footerSeparators:     p.footerSeparators,
+		gitTrailerSeparators: p.gitTrailerSeparators,
 	}
 
-	footerCount := 0
-
 	footerStartPos := 0
 	footerEndPos := 0
 
+	// git trailers continue their value on following lines
+	hasValue := false
+
 	for {
 		t, done := lex.NextToken()
 		if done {
@@ -43,24 +97,47 @@ func (p *Parser) parse(input string) (*Commit, error) {
 			c.isBreakingChange = true
 		case headerTypeToken:
 			c.commitType = t.Value
+			c.typeSpan = Span{Start: t.Start, End: t.End}
 		case headerScopeToken:
 			c.scope = t.Value
+			c.scopeSpan = Span{Start: t.Start, End: t.End}
 		case descriptionToken:
 			c.description = t.Value
+			c.descriptionSpan = Span{Start: t.Start, End: t.End}
 			c.header = strings.TrimSpace(lex.Get(0, t.End))
+			c.headerSpan = trimmedSpan(input, 0, t.End)
 		case bodyToken:
 			c.body = strings.TrimSpace(t.Value)
-		case footerKeyToken:
+			c.bodySpan = trimmedSpan(input, t.Start, t.End)
+		case footerKeyToken, footerBreakingKeyToken:
 			if footerStartPos == 0 {
 				footerStartPos = t.Start
 			}
 			n := Note{
-				token: t.Value,
+				token:      t.Value,
+				isBreaking: t.Type == footerBreakingKeyToken,
+				span:       Span{Start: t.Start, End: t.End},
+			}
+			if n.isBreaking {
+				c.isBreakingChange = true
 			}
 			c.notes = append(c.notes, n)
+			footerEndPos = t.End
+			hasValue = false
+		case footerDelimterToken:
+			n := &c.notes[len(c.notes)-1]
+			n.separator = t.Value
+			n.span = trimmedSpan(input, n.span.Start, t.End)
+			footerEndPos = t.End
 		case footerValueToken:
-			c.notes[footerCount].value = strings.TrimSpace(t.Value)
-			footerCount++
+			n := &c.notes[len(c.notes)-1]
+			if hasValue {
+				n.value = unfoldGitValue(n.value + "\n" + t.Value)
+			} else {
+				n.value = strings.TrimSpace(t.Value)
+			}
+			n.span = trimmedSpan(input, n.span.Start, t.End)
+			hasValue = true
 			footerEndPos = t.End
 		}
 	}
@@ -71,6 +148,19 @@ func (p *Parser) parse(input string) (*Commit, error) {
 
 	if footerStartPos != 0 {
 		c.footer = strings.TrimSpace(lex.Get(footerStartPos, footerEndPos))
+		c.footerSpan = trimmedSpan(input, footerStartPos, footerEndPos)
+	}
+
+	if p.gitTrailerSeparators != "" && hasBreakingChangeLine(c.body, p.breakingTokens) {
+		c.isBreakingChange = true
+	}
+
+	if err := p.mode.validate(c); err != nil {
+		return nil, err
+	}
+
+	if err := p.resolveType(c); err != nil {
+		return nil, err
 	}
 
 	return c, nil
@@ -0,0 +1,49 @@
+package parser
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParserErrorPosition(t *testing.T) {
+	var cases = []struct {
+		message string
+		mode    Mode
+		span    string
+		fixed   string
+	}{
+		{"feat: description\nbody", ModeLenient, "b", "feat: description\n\nbody"},
+		{"feat: description\n\nbody\nRefs: #1", ModeLenient, "R", "feat: description\n\nbody\n\nRefs: #1"},
+		{"feat:description", ModeLenient, "d", "feat: description"},
+		{"feat(scope) description", ModeLenient, "d", ""},
+		{"fe@t: description", ModeStrictV100, "@", ""},
+		{"feat:  description", ModeStrictV100, " ", "feat: description"},
+		{"feat: description\n\nbreaking-change: reason", ModeStrictV100, "breaking-change", "feat: description\n\nBREAKING-CHANGE: reason"},
+	}
+
+	for _, tc := range cases {
+		_, err := New(WithMode(tc.mode)).Parse(tc.message)
+
+		var perr *Error
+		if !errors.As(err, &perr) {
+			t.Errorf("Parse(%q) = %v, expected *Error", tc.message, err)
+			continue
+		}
+
+		if actual := tc.message[perr.Span.Start:perr.Span.End]; actual != tc.span {
+			t.Errorf("Parse(%q) error span %q, expected %q", tc.message, actual, tc.span)
+		}
+
+		fixed := ""
+		if len(perr.Edits) > 0 {
+			fixed = tc.message
+			for i := len(perr.Edits) - 1; i >= 0; i-- {
+				e := perr.Edits[i]
+				fixed = fixed[:e.Span.Start] + e.Text + fixed[e.Span.End:]
+			}
+		}
+		if fixed != tc.fixed {
+			t.Errorf("Parse(%q) error fixed to %q, expected %q", tc.message, fixed, tc.fixed)
+		}
+	}
+}
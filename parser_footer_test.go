@@ -0,0 +1,134 @@
+package parser
+
+import (
+	"testing"
+)
+
+func TestParserFooterSeparator(t *testing.T) {
+	c, err := New().Parse("feat: description\n\nRefs: #123\nCloses #45\nBREAKING-CHANGE: reason")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var expected = []struct {
+		token, separator, value string
+		isBreaking              bool
+	}{
+		{"Refs", ": ", "#123", false},
+		{"Closes", " #", "45", false},
+		{"BREAKING-CHANGE", ": ", "reason", true},
+	}
+
+	notes := c.Notes()
+	if len(notes) != len(expected) {
+		t.Fatalf("expected %d notes, got %d", len(expected), len(notes))
+	}
+
+	for i, e := range expected {
+		n := notes[i]
+		if n.Token() != e.token || n.Separator() != e.separator || n.Value() != e.value || n.IsBreakingChange() != e.isBreaking {
+			t.Errorf("note %d: got %q %q %q %v", i, n.Token(), n.Separator(), n.Value(), n.IsBreakingChange())
+		}
+		if n.String() != e.token+e.separator+e.value {
+			t.Errorf("note %d: String() = %q", i, n.String())
+		}
+	}
+}
+
+func TestParserCustomFooterSeparators(t *testing.T) {
+	p := New(WithFooterSeparators("=", ":"))
+
+	c, err := p.Parse("feat: description\n\nbody\n\nReviewed-by=John Doe\nRefs:#123\nAcked-by: Jane Doe")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Body() != "body" {
+		t.Errorf("Body() = %q", c.Body())
+	}
+
+	var expected = []string{"Reviewed-by=John Doe", "Refs:#123", "Acked-by: Jane Doe"}
+
+	notes := c.Notes()
+	if len(notes) != len(expected) {
+		t.Fatalf("expected %d notes, got %d", len(expected), len(notes))
+	}
+	for i, e := range expected {
+		if notes[i].String() != e {
+			t.Errorf("note %d: got %q, expected %q", i, notes[i].String(), e)
+		}
+	}
+
+	if notes[2].Separator() != ": " {
+		t.Errorf("longest separator not preferred, got %q", notes[2].Separator())
+	}
+}
+
+func TestParserCustomBreakingChangeTokens(t *testing.T) {
+	p := New(WithBreakingChangeTokens("BREAKING", "INCOMPATIBLE CHANGE"))
+
+	var cases = map[string]bool{
+		"feat: description\n\nBREAKING: reason":            true,
+		"feat: description\n\nINCOMPATIBLE CHANGE: reason": true,
+		"feat: description\n\nBREAKING CHANGE: reason":     true,
+		"feat: description\n\nBreaking: reason":            false,
+	}
+
+	for msg, expected := range cases {
+		c, err := p.Parse(msg)
+		if err != nil {
+			t.Errorf("Parse(%q) failed: %v", msg, err)
+			continue
+		}
+		if c.IsBreakingChange() != expected {
+			t.Errorf("Parse(%q).IsBreakingChange() = %v", msg, c.IsBreakingChange())
+		}
+		if len(c.Notes()) != 1 || c.Notes()[0].Value() != "reason" {
+			t.Errorf("Parse(%q) notes = %v", msg, c.Notes())
+		}
+	}
+}
+
+func TestParserBreakingChangeSeparator(t *testing.T) {
+	var cases = []string{
+		"feat: description\n\nBREAKING CHANGE #123",
+		"feat: description\n\nBREAKING CHANGE:reason",
+		"feat: description\n\nBREAKING CHANGE=reason",
+		"feat: description\n\nBREAKING-CHANGE:reason",
+		"feat: description\n\nBREAKING-CHANGE=reason",
+		"feat: description\n\nBREAKING=reason",
+	}
+
+	for _, mode := range []Mode{ModeLenient, ModeStrictV100} {
+		p := New(WithMode(mode), WithFooterSeparators(":", "="), WithBreakingChangeTokens("BREAKING"))
+
+		for i, msg := range cases {
+			c, err := p.Parse(msg)
+			if mode == ModeStrictV100 {
+				if err == nil && i < len(cases)-1 {
+					t.Errorf("case#%d: %s passed for %q", i, mode, msg)
+				}
+				continue
+			}
+			if err != nil {
+				t.Errorf("case#%d: Parse(%q) failed: %v", i, msg, err)
+				continue
+			}
+			if c.IsBreakingChange() || len(c.Notes()) != 0 {
+				t.Errorf("case#%d: Parse(%q) has footer, breaking %v, notes %v", i, msg, c.IsBreakingChange(), c.Notes())
+			}
+		}
+	}
+}
+
+func TestParserStrictBreakingChangeSeparator(t *testing.T) {
+	p := New(WithMode(ModeStrictV100), WithFooterSeparators(":"))
+
+	if _, err := p.Parse("feat: description\n\nBREAKING CHANGE:reason"); err == nil {
+		t.Error("breaking change without ': ' passed strict mode")
+	}
+
+	if _, err := p.Parse("feat: description\n\nRefs:#123"); err == nil {
+		t.Error("custom separator passed strict mode")
+	}
+}
@@ -0,0 +1,49 @@
+package parser
+
+import (
+	"testing"
+)
+
+func TestParserSpans(t *testing.T) {
+	msg := "feat(scope)!: description\n\nbody line\n\nRefs: #123\nBREAKING CHANGE: reason\n  continued"
+
+	c, err := New().Parse(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var cases = []struct {
+		name     string
+		span     Span
+		expected string
+	}{
+		{"header", c.HeaderSpan(), "feat(scope)!: description"},
+		{"type", c.TypeSpan(), "feat"},
+		{"scope", c.ScopeSpan(), "scope"},
+		{"description", c.DescriptionSpan(), "description"},
+		{"body", c.BodySpan(), "body line"},
+		{"footer", c.FooterSpan(), "Refs: #123\nBREAKING CHANGE: reason\n  continued"},
+		{"note 1", c.Notes()[0].Span(), "Refs: #123"},
+		{"note 2", c.Notes()[1].Span(), "BREAKING CHANGE: reason\n  continued"},
+	}
+
+	for _, tc := range cases {
+		actual := c.Message()[tc.span.Start:tc.span.End]
+		if actual != tc.expected {
+			t.Errorf("%s span: got %q, expected %q", tc.name, actual, tc.expected)
+		}
+	}
+}
+
+func TestParserSpansMissingParts(t *testing.T) {
+	c, err := New().Parse("feat: description")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, span := range []Span{c.ScopeSpan(), c.BodySpan(), c.FooterSpan()} {
+		if span.Len() != 0 {
+			t.Errorf("expected empty span, got %v", span)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -231,7 +232,7 @@ func TestParserErrNoBlankLine(t *testing.T) {
 		return
 	}
 
-	if err != errHeaderMissingEmptyLine {
+	if !errors.Is(err, errHeaderMissingEmptyLine) {
 		t.Error("error is not NoBlankLineErr error", err)
 	}
 }
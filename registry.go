@@ -0,0 +1,198 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Impact is the semantic versioning impact of a commit
+type Impact int
+
+const (
+	// ImpactNone does not require a release
+	ImpactNone Impact = iota
+	// ImpactPatch requires a patch release
+	ImpactPatch
+	// ImpactMinor requires a minor release
+	ImpactMinor
+	// ImpactMajor requires a major release
+	ImpactMajor
+)
+
+var (
+	errTypeNameEmpty = errors.New("registry: type name is empty")
+
+	errUnknownType       = "type: unknown type %q"
+	errTypeAlreadyExists = "registry: type or alias %q already registered"
+	errTypeAliasRepeated = "registry: alias %q repeated in type %q"
+)
+
+// String returns the name of the impact
+func (i Impact) String() string {
+	switch i {
+	case ImpactNone:
+		return "none"
+	case ImpactPatch:
+		return "patch"
+	case ImpactMinor:
+		return "minor"
+	case ImpactMajor:
+		return "major"
+	default:
+		return fmt.Sprintf("Impact(%d)", int(i))
+	}
+}
+
+// TypeInfo describes a commit type
+type TypeInfo struct {
+	// Name is the canonical name of the type, like "feat"
+	Name string
+	// Aliases are alternative names resolved to Name, like "feature"
+	Aliases []string
+	// Description is a human readable description of the type
+	Description string
+	// Impact is the semantic versioning impact of commits of this type
+	Impact Impact
+	// Changelog reports whether commits of this type are listed in changelogs
+	Changelog bool
+}
+
+// TypeRegistry holds the known commit types. Names and aliases are case
+// insensitive. The zero value is an empty registry ready to use.
+type TypeRegistry struct {
+	types  []TypeInfo
+	lookup map[string]int
+}
+
+// NewTypeRegistry returns a registry with the given types
+func NewTypeRegistry(types ...TypeInfo) (*TypeRegistry, error) {
+	r := &TypeRegistry{}
+
+	for _, t := range types {
+		if err := r.Register(t); err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
+
+// DefaultTypeRegistry returns a registry with the types of the conventional commits preset
+func DefaultTypeRegistry() *TypeRegistry {
+	r, err := NewTypeRegistry(defaultTypes...)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+var defaultTypes = []TypeInfo{
+	{Name: "feat", Aliases: []string{"feature"}, Description: "A new feature", Impact: ImpactMinor, Changelog: true},
+	{Name: "fix", Aliases: []string{"bugfix"}, Description: "A bug fix", Impact: ImpactPatch, Changelog: true},
+	{Name: "perf", Description: "A code change that improves performance", Impact: ImpactPatch, Changelog: true},
+	{Name: "revert", Description: "Reverts a previous commit", Impact: ImpactPatch, Changelog: true},
+	{Name: "docs", Aliases: []string{"doc"}, Description: "Documentation only changes"},
+	{Name: "style", Description: "Changes that do not affect the meaning of the code"},
+	{Name: "refactor", Description: "A code change that neither fixes a bug nor adds a feature"},
+	{Name: "test", Aliases: []string{"tests"}, Description: "Adding missing tests or correcting existing tests"},
+	{Name: "build", Description: "Changes that affect the build system or external dependencies"},
+	{Name: "ci", Description: "Changes to the CI configuration files and scripts"},
+	{Name: "chore", Description: "Other changes that don't modify source or test files"},
+}
+
+// Register adds a type to the registry. Its name and aliases must be
+// unique, within the type and among the registered types.
+func (r *TypeRegistry) Register(t TypeInfo) error {
+	if t.Name == "" {
+		return errTypeNameEmpty
+	}
+
+	keys := append([]string{t.Name}, t.Aliases...)
+	seen := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		lower := strings.ToLower(k)
+		if seen[lower] {
+			return fmt.Errorf(errTypeAliasRepeated, k, t.Name)
+		}
+		if _, ok := r.lookup[lower]; ok {
+			return fmt.Errorf(errTypeAlreadyExists, k)
+		}
+		seen[lower] = true
+	}
+
+	if r.lookup == nil {
+		r.lookup = make(map[string]int)
+	}
+	r.types = append(r.types, t)
+	for _, k := range keys {
+		r.lookup[strings.ToLower(k)] = len(r.types) - 1
+	}
+
+	return nil
+}
+
+// Lookup returns the type registered with the given name or alias
+func (r *TypeRegistry) Lookup(name string) (TypeInfo, bool) {
+	i, ok := r.lookup[strings.ToLower(name)]
+	if !ok {
+		return TypeInfo{}, false
+	}
+	return r.types[i], true
+}
+
+// Types returns all registered types in registration order
+func (r *TypeRegistry) Types() []TypeInfo {
+	types := make([]TypeInfo, len(r.types))
+	copy(types, r.types)
+	return types
+}
+
+// Impact returns the semantic versioning impact of the commit. Breaking
+// changes are always ImpactMajor, unknown types are ImpactNone.
+func (r *TypeRegistry) Impact(c *Commit) Impact {
+	if c.IsBreakingChange() {
+		return ImpactMajor
+	}
+
+	t, ok := r.Lookup(c.Type())
+	if !ok {
+		return ImpactNone
+	}
+	return t.Impact
+}
+
+// WithTypeRegistry sets the registry used to resolve the canonical type of commits
+func WithTypeRegistry(r *TypeRegistry) Option {
+	return func(p *Parser) {
+		p.registry = r
+	}
+}
+
+// WithUnknownTypesRejected makes the parser return an error for types that
+// are not in the registry. The default registry is used if none is set.
+func WithUnknownTypesRejected() Option {
+	return func(p *Parser) {
+		p.rejectUnknownTypes = true
+	}
+}
+
+// resolveType sets the canonical type of the commit
+func (p *Parser) resolveType(c *Commit) error {
+	c.canonicalType = c.commitType
+
+	if p.registry == nil {
+		return nil
+	}
+
+	t, ok := p.registry.Lookup(c.commitType)
+	if !ok {
+		if p.rejectUnknownTypes {
+			return newError(fmt.Errorf(errUnknownType, c.commitType), c.typeSpan)
+		}
+		return nil
+	}
+
+	c.canonicalType = t.Name
+	return nil
+}
@@ -0,0 +1,123 @@
+package parser
+
+import (
+	"testing"
+)
+
+func TestTypeRegistryLookup(t *testing.T) {
+	r := DefaultTypeRegistry()
+
+	var cases = []struct {
+		name      string
+		canonical string
+		impact    Impact
+	}{
+		{"feat", "feat", ImpactMinor},
+		{"Feature", "feat", ImpactMinor},
+		{"FIX", "fix", ImpactPatch},
+		{"bugfix", "fix", ImpactPatch},
+		{"docs", "docs", ImpactNone},
+	}
+
+	for _, tc := range cases {
+		info, ok := r.Lookup(tc.name)
+		if !ok {
+			t.Errorf("type %q not found", tc.name)
+			continue
+		}
+		if info.Name != tc.canonical || info.Impact != tc.impact {
+			t.Errorf("Lookup(%q) = %s/%s, expected %s/%s", tc.name, info.Name, info.Impact, tc.canonical, tc.impact)
+		}
+	}
+
+	if _, ok := r.Lookup("unknown"); ok {
+		t.Error("unknown type found in registry")
+	}
+}
+
+func TestTypeRegistryRegisterDuplicate(t *testing.T) {
+	_, err := NewTypeRegistry(
+		TypeInfo{Name: "feat"},
+		TypeInfo{Name: "feature", Aliases: []string{"FEAT"}},
+	)
+	if err == nil {
+		t.Error("registering a duplicate alias passed without error")
+	}
+}
+
+func TestTypeRegistryRegisterRepeatedAlias(t *testing.T) {
+	var cases = []TypeInfo{
+		{Name: "feat", Aliases: []string{"feature", "Feature"}},
+		{Name: "fix", Aliases: []string{"FIX"}},
+	}
+
+	for i, tc := range cases {
+		if _, err := NewTypeRegistry(tc); err == nil {
+			t.Errorf("case#%d: registering %v passed without error", i, tc)
+		}
+	}
+}
+
+func TestTypeRegistryZeroValue(t *testing.T) {
+	var r TypeRegistry
+	if err := r.Register(TypeInfo{Name: "feat", Aliases: []string{"feature"}}); err != nil {
+		t.Fatal(err)
+	}
+	if info, ok := r.Lookup("Feature"); !ok || info.Name != "feat" {
+		t.Errorf("Lookup(Feature) = %v, %v, expected feat", info, ok)
+	}
+}
+
+func TestTypeRegistryImpact(t *testing.T) {
+	r := DefaultTypeRegistry()
+	p := New()
+
+	var cases = map[string]Impact{
+		"feat: description":   ImpactMinor,
+		"fix!: description":   ImpactMajor,
+		"chore: description":  ImpactNone,
+		"custom: description": ImpactNone,
+	}
+
+	for msg, expected := range cases {
+		c, err := p.Parse(msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if impact := r.Impact(c); impact != expected {
+			t.Errorf("Impact(%q) = %s, expected %s", msg, impact, expected)
+		}
+	}
+}
+
+func TestParserCanonicalType(t *testing.T) {
+	p := New(WithTypeRegistry(DefaultTypeRegistry()))
+
+	c, err := p.Parse("feature(api): description")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Type() != "feature" || c.CanonicalType() != "feat" {
+		t.Errorf("Type() = %q, CanonicalType() = %q", c.Type(), c.CanonicalType())
+	}
+
+	c, err = p.Parse("custom: description")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.CanonicalType() != "custom" {
+		t.Errorf("CanonicalType() = %q, expected %q", c.CanonicalType(), "custom")
+	}
+}
+
+func TestParserRejectUnknownTypes(t *testing.T) {
+	p := New(WithUnknownTypesRejected())
+
+	if _, err := p.Parse("feat: description"); err != nil {
+		t.Error("known type rejected", err)
+	}
+
+	if _, err := p.Parse("custom: description"); err == nil {
+		t.Error("unknown type passed without error")
+	}
+}
@@ -0,0 +1,170 @@
+package report
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// jsonEntry is the JSON form of an Entry
+type jsonEntry struct {
+	Source      string           `json:"source"`
+	Valid       bool             `json:"valid"`
+	Diagnostics []jsonDiagnostic `json:"diagnostics"`
+}
+
+type jsonDiagnostic struct {
+	Rule     string     `json:"rule"`
+	Severity string     `json:"severity"`
+	Message  string     `json:"message"`
+	Start    Position   `json:"start"`
+	End      Position   `json:"end"`
+	Fix      []jsonEdit `json:"fix,omitempty"`
+}
+
+// jsonEdit replaces the text between start and end
+type jsonEdit struct {
+	Start Position `json:"start"`
+	End   Position `json:"end"`
+	Text  string   `json:"text"`
+}
+
+// JSON writes the entries as JSON array of objects with source, validity and
+// diagnostics. Diagnostics with a fix hold its edits.
+func JSON(w io.Writer, entries []Entry) error {
+	out := make([]jsonEntry, len(entries))
+
+	for i, e := range entries {
+		out[i] = jsonEntry{
+			Source:      e.Source,
+			Valid:       e.Result.Valid(),
+			Diagnostics: []jsonDiagnostic{},
+		}
+
+		for _, d := range e.Result.Diagnostics {
+			start, end := positions(e.Result.Message, d.Span)
+			jd := jsonDiagnostic{
+				Rule:     d.Rule,
+				Severity: d.Severity.String(),
+				Message:  d.Message,
+				Start:    start,
+				End:      end,
+			}
+			if d.Fix != nil {
+				for _, edit := range d.Fix.Edits {
+					start, end := positions(e.Result.Message, edit.Span)
+					jd.Fix = append(jd.Fix, jsonEdit{Start: start, End: end, Text: edit.Text})
+				}
+			}
+			out[i].Diagnostics = append(out[i].Diagnostics, jd)
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+// SARIF writes a SARIF 2.1.0 log with one run, see
+// https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html
+func SARIF(w io.Writer, entries []Entry) error {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           ToolName,
+			InformationURI: ToolURI,
+			Rules:          []sarifRule{},
+		}},
+		ColumnKind: "unicodeCodePoints",
+		Results:    []sarifResult{},
+	}
+
+	rules := map[string]bool{}
+	for _, e := range entries {
+		for _, d := range e.Result.Diagnostics {
+			if !rules[d.Rule] {
+				rules[d.Rule] = true
+				run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{ID: d.Rule})
+			}
+
+			start, end := positions(e.Result.Message, d.Span)
+			run.Results = append(run.Results, sarifResult{
+				RuleID:  d.Rule,
+				Level:   d.Severity.String(),
+				Message: sarifMessage{Text: d.Message},
+				Locations: []sarifLocation{{PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: e.Source},
+					Region: sarifRegion{
+						StartLine:   start.Line,
+						StartColumn: start.Column,
+						EndLine:     end.Line,
+						EndColumn:   end.Column,
+					},
+				}}},
+			})
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{run},
+	})
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool       sarifTool     `json:"tool"`
+	ColumnKind string        `json:"columnKind"`
+	Results    []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID string `json:"id"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn"`
+	EndLine     int `json:"endLine"`
+	EndColumn   int `json:"endColumn"`
+}
@@ -0,0 +1,278 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/conventionalcommit/parser"
+	"github.com/conventionalcommit/parser/lint"
+	"github.com/conventionalcommit/parser/width"
+)
+
+// maxSnippetLines limits the lines shown for a diagnostic spanning many lines
+const maxSnippetLines = 4
+
+// ANSI escape sequences of the colors used by Pretty
+const (
+	ansiReset   = "\x1b[0m"
+	ansiBold    = "\x1b[1m"
+	ansiRed     = "\x1b[1;31m"
+	ansiYellow  = "\x1b[1;33m"
+	ansiBlue    = "\x1b[1;34m"
+	ansiCyan    = "\x1b[1;36m"
+	ellipsis    = "…"
+	ellipsisLen = 1
+)
+
+// Pretty renders diagnostics the way compilers do, with the lines of the
+// message the diagnostic refers to, carets under its position, the rule as
+// error code and the fix as help:
+//
+//	error[subject-full-stop]: subject may not end with full stop
+//	 --> .git/COMMIT_EDITMSG:1:12
+//	  |
+//	1 | feat: add x.
+//	  |            ^
+//	  = help: remove `.`
+type Pretty struct {
+	// Color enables ANSI colors
+	Color bool
+	// Width is the width of the terminal, longer lines are cut around the
+	// position of the diagnostic. A width below 1 disables cutting.
+	Width int
+}
+
+// Report writes the diagnostics of all entries, followed by a summary
+func (p Pretty) Report(w io.Writer, entries []Entry) error {
+	var b strings.Builder
+	errors, warnings := 0, 0
+
+	for _, e := range entries {
+		for _, d := range e.Result.Diagnostics {
+			p.diagnostic(&b, e, d)
+			b.WriteString("\n")
+
+			if d.Severity == lint.SeverityError {
+				errors++
+			} else {
+				warnings++
+			}
+		}
+	}
+
+	if errors+warnings > 0 {
+		summary := fmt.Sprintf("%s, %s emitted", plural(errors, "error"), plural(warnings, "warning"))
+		b.WriteString(p.paint(ansiBold, summary) + "\n")
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// diagnostic renders a single diagnostic
+func (p Pretty) diagnostic(b *strings.Builder, e Entry, d lint.Diagnostic) {
+	msg := e.Result.Message
+	start := position(msg, d.Span.Start)
+
+	severityColor := ansiYellow
+	if d.Severity == lint.SeverityError {
+		severityColor = ansiRed
+	}
+
+	lines := strings.Split(msg, "\n")
+	last := position(msg, d.Span.End).Line
+	if d.Span.End > d.Span.Start && strings.HasSuffix(msg[:d.Span.End], "\n") {
+		last--
+	}
+	if last < start.Line {
+		last = start.Line
+	}
+	shown := last
+	if shown-start.Line >= maxSnippetLines {
+		shown = start.Line + maxSnippetLines - 1
+	}
+
+	gutter := strings.Repeat(" ", len(strconv.Itoa(shown)))
+	bar := p.paint(ansiBlue, gutter+" |")
+
+	fmt.Fprintf(b, "%s%s\n", p.paint(severityColor, fmt.Sprintf("%s[%s]", d.Severity, d.Rule)), p.paint(ansiBold, ": "+d.Message))
+	fmt.Fprintf(b, "%s %s:%d:%d\n", p.paint(ansiBlue, gutter+"-->"), e.Source, start.Line, start.Column)
+	b.WriteString(bar + "\n")
+
+	lineStart := lineOffset(lines, start.Line)
+	for n := start.Line; n <= shown && n <= len(lines); n++ {
+		line := lines[n-1]
+
+		from, to := 0, len(line)
+		if n == start.Line {
+			from = d.Span.Start - lineStart
+		}
+		if lineStart+len(line) >= d.Span.End {
+			to = d.Span.End - lineStart
+		}
+		if to < from {
+			to = from
+		}
+
+		text, caretCol, caretLen := p.snippet(line, from, to, len(gutter)+3)
+
+		num := strconv.Itoa(n)
+		fmt.Fprintf(b, "%s %s\n", p.paint(ansiBlue, strings.Repeat(" ", len(gutter)-len(num))+num+" |"), text)
+		carets := strings.Repeat(" ", caretCol) + strings.Repeat("^", caretLen)
+		fmt.Fprintf(b, "%s %s\n", bar, p.paint(severityColor, carets))
+
+		lineStart += len(line) + 1
+	}
+	if shown < last {
+		fmt.Fprintf(b, "%s ...\n", bar)
+	}
+
+	if help := describeFix(msg, d.Fix); help != "" {
+		fmt.Fprintf(b, "%s %s\n", p.paint(ansiBlue, gutter+" ="), p.paint(ansiCyan, "help: ")+help)
+	}
+}
+
+// snippet returns the line as displayed, with tabs expanded and cut to the
+// terminal width, and the column and width of the carets under line[from:to]
+func (p Pretty) snippet(line string, from, to, indent int) (string, int, int) {
+	before := expandTabs(line[:from], 0)
+	caretCol := width.String(before)
+	marked := expandTabs(line[from:to], caretCol)
+	caretLen := width.String(marked)
+	after := expandTabs(line[to:], caretCol+caretLen)
+
+	if caretLen == 0 {
+		caretLen = 1
+	}
+
+	text := before + marked + after
+	avail := p.Width - indent
+	if p.Width < 1 || width.String(text) <= avail {
+		return text, caretCol, caretLen
+	}
+
+	// keep a third of the available width before the position, unless the
+	// end of the line fits
+	textWidth := width.String(text)
+	skip := caretCol - avail/3
+	if maxSkip := textWidth - (avail - ellipsisLen); skip > maxSkip {
+		skip = maxSkip
+	}
+	if skip < 0 {
+		skip = 0
+	}
+
+	var out strings.Builder
+	col, used := 0, 0
+	if skip > 0 {
+		out.WriteString(ellipsis)
+		used = ellipsisLen
+	}
+
+	for _, g := range width.Graphemes(text) {
+		gw := width.String(g)
+		if col < skip {
+			col += gw
+			continue
+		}
+		if used+textWidth-col > avail && used+gw > avail-ellipsisLen {
+			out.WriteString(ellipsis)
+			break
+		}
+		out.WriteString(g)
+		used += gw
+		col += gw
+	}
+
+	caretCol = caretCol - skip
+	if skip > 0 {
+		caretCol += ellipsisLen
+	}
+	if caretCol+caretLen > avail {
+		caretLen = avail - caretCol
+		if caretLen < 1 {
+			caretLen = 1
+		}
+	}
+	return out.String(), caretCol, caretLen
+}
+
+func (p Pretty) paint(color, text string) string {
+	if !p.Color || text == "" {
+		return text
+	}
+	return color + text + ansiReset
+}
+
+// describeFix returns a description of the edits of a fix, like "remove `.`"
+func describeFix(msg string, fix *lint.Fix) string {
+	if fix == nil {
+		return ""
+	}
+
+	descs := make([]string, len(fix.Edits))
+	for i, e := range fix.Edits {
+		descs[i] = describeEdit(msg, e)
+	}
+	return strings.Join(descs, ", ")
+}
+
+func describeEdit(msg string, e parser.Edit) string {
+	old := msg[e.Span.Start:e.Span.End]
+
+	switch {
+	case old == "" && e.Text == "\n":
+		return "insert a line break"
+	case old == "" && e.Text == " ":
+		return "insert a space"
+	case old == "":
+		return fmt.Sprintf("insert `%s`", e.Text)
+	case e.Text == "" && strings.TrimSpace(old) == "":
+		return "remove the whitespace"
+	case e.Text == "":
+		return fmt.Sprintf("remove `%s`", old)
+	case strings.Contains(old, "\n") || strings.Contains(e.Text, "\n"):
+		return "reflow the text"
+	default:
+		return fmt.Sprintf("replace `%s` with `%s`", old, e.Text)
+	}
+}
+
+// lineOffset returns the byte offset of line n, starting at 1
+func lineOffset(lines []string, n int) int {
+	offset := 0
+	for i := 0; i < n-1 && i < len(lines); i++ {
+		offset += len(lines[i]) + 1
+	}
+	return offset
+}
+
+// expandTabs replaces the tabs of s, which starts at column col, with
+// spaces up to the next tab stop
+func expandTabs(s string, col int) string {
+	if !strings.Contains(s, "\t") {
+		return s
+	}
+
+	var b strings.Builder
+	for _, g := range width.Graphemes(s) {
+		if g == "\t" {
+			n := width.TabWidth - col%width.TabWidth
+			b.WriteString(strings.Repeat(" ", n))
+			col += n
+			continue
+		}
+		b.WriteString(g)
+		col += width.String(g)
+	}
+	return b.String()
+}
+
+func plural(n int, word string) string {
+	if n == 1 {
+		return "1 " + word
+	}
+	return fmt.Sprintf("%d %ss", n, word)
+}
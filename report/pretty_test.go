@@ -0,0 +1,102 @@
+package report
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/conventionalcommit/parser"
+	"github.com/conventionalcommit/parser/lint"
+)
+
+func renderPretty(t *testing.T, p Pretty, l *lint.Linter, msg string) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := p.Report(&buf, []Entry{{Source: "MSG", Result: l.Lint(msg)}}); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+func TestPrettyWideCharacters(t *testing.T) {
+	l := lint.New(parser.New())
+	l.Add(lint.SeverityError, &lint.FullStop{Part: lint.PartSubject, When: lint.Never, Value: "。"})
+
+	out := renderPretty(t, Pretty{}, l, "fix: 修复登录。")
+	expected := "1 | fix: 修复登录。\n  |              ^^\n"
+	if !strings.Contains(out, expected) {
+		t.Errorf("carets not aligned to display width:\n%s", out)
+	}
+}
+
+func TestPrettyTabStops(t *testing.T) {
+	l := lint.New(parser.New())
+	l.Add(lint.SeverityError, &lint.FullStop{Part: lint.PartBody, When: lint.Never, Value: "."})
+
+	out := renderPretty(t, Pretty{}, l, "fix: x\n\nab\tc\t.")
+	expected := "3 | ab  c   .\n  |         ^\n"
+	if !strings.Contains(out, expected) {
+		t.Errorf("tabs not expanded to tab stops:\n%s", out)
+	}
+}
+
+func TestPrettyMultipleLines(t *testing.T) {
+	l := lint.New(parser.New())
+	l.Add(lint.SeverityWarning, &lint.Empty{Part: lint.PartBody, When: lint.Always})
+
+	out := renderPretty(t, Pretty{}, l, "fix: x\n\nfirst\nsecond\nthird\nfourth\nfifth")
+	expected := "warning[body-empty]: body must be empty\n --> MSG:3:1\n  |\n3 | first\n  | ^^^^^\n4 | second\n  | ^^^^^^\n5 | third\n  | ^^^^^\n6 | fourth\n  | ^^^^^^\n  | ...\n\n0 errors, 1 warning emitted\n"
+	if out != expected {
+		t.Errorf("Report() =\n%s\nexpected\n%s", out, expected)
+	}
+}
+
+func TestPrettyWidth(t *testing.T) {
+	l := lint.New(parser.New())
+	l.Add(lint.SeverityError, &lint.FullStop{Part: lint.PartSubject, When: lint.Never})
+
+	msg := "fix: " + strings.Repeat("word ", 20) + "end."
+	out := renderPretty(t, Pretty{Width: 40}, l, msg)
+
+	expected := "1 | … word word word word word word end.\n  |                                    ^\n"
+	if !strings.Contains(out, expected) {
+		t.Errorf("line not cut around position:\n%s", out)
+	}
+
+	l = lint.New(parser.New())
+	l.Add(lint.SeverityError, spanRule{"end"})
+
+	msg = "fix: " + strings.Repeat("word ", 10) + "end" + strings.Repeat(" more", 10)
+	out = renderPretty(t, Pretty{Width: 40}, l, msg)
+	expected = "1 | …d word word end more more more mor…\n  |              ^^^\n"
+	if !strings.Contains(out, expected) {
+		t.Errorf("line not cut on both sides:\n%s", out)
+	}
+}
+
+// spanRule reports the first occurrence of a text in the message
+type spanRule struct {
+	text string
+}
+
+func (r spanRule) Name() string {
+	return "span"
+}
+
+func (r spanRule) Check(c *parser.Commit) []lint.Problem {
+	i := strings.Index(c.Message(), r.text)
+	return []lint.Problem{{Message: "found", Span: parser.Span{Start: i, End: i + len(r.text)}}}
+}
+
+func TestPrettyColor(t *testing.T) {
+	l := lint.New(parser.New())
+
+	out := renderPretty(t, Pretty{Color: true}, l, "fix: x\nbody")
+	if !strings.HasPrefix(out, ansiRed+"error[parse]"+ansiReset) {
+		t.Errorf("missing colors:\n%q", out)
+	}
+	if strings.Contains(renderPretty(t, Pretty{}, l, "fix: x\nbody"), "\x1b[") {
+		t.Error("colors without Color")
+	}
+}
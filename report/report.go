@@ -0,0 +1,105 @@
+// Package report writes lint results in formats for humans, CI systems and
+// code scanning tools, like SARIF, JUnit XML or GitHub workflow commands
+package report
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/conventionalcommit/parser"
+	"github.com/conventionalcommit/parser/lint"
+)
+
+// ToolName is the name reports use for the linter
+const ToolName = "conventionalcommit"
+
+// ToolURI is the homepage reports link for the linter
+const ToolURI = "https://github.com/conventionalcommit/parser"
+
+var errUnknownFormat = "unknown report format %q, supported formats are %s"
+
+// Entry is the lint result of one commit message
+type Entry struct {
+	// Source identifies the message, like a file name or a commit hash
+	Source string
+	// Result is the lint result of the message
+	Result *lint.Result
+}
+
+// Reporter writes the lint results of commit messages in a format
+type Reporter interface {
+	Report(w io.Writer, entries []Entry) error
+}
+
+// ReporterFunc is a function used as Reporter
+type ReporterFunc func(w io.Writer, entries []Entry) error
+
+// Report calls f
+func (f ReporterFunc) Report(w io.Writer, entries []Entry) error {
+	return f(w, entries)
+}
+
+// reporters are the reporters by format name
+var reporters = map[string]Reporter{
+	"plain":      ReporterFunc(Plain),
+	"json":       ReporterFunc(JSON),
+	"sarif":      ReporterFunc(SARIF),
+	"junit":      ReporterFunc(JUnit),
+	"checkstyle": ReporterFunc(Checkstyle),
+	"github":     ReporterFunc(GitHub),
+	"pretty":     Pretty{},
+}
+
+// Register adds a reporter for a format, replacing an existing one
+func Register(format string, r Reporter) {
+	reporters[format] = r
+}
+
+// New returns the reporter of a format, like "sarif"
+func New(format string) (Reporter, error) {
+	r, ok := reporters[format]
+	if !ok {
+		return nil, fmt.Errorf(errUnknownFormat, format, strings.Join(Formats(), ", "))
+	}
+	return r, nil
+}
+
+// Formats returns the names of all formats, sorted
+func Formats() []string {
+	formats := make([]string, 0, len(reporters))
+	for name := range reporters {
+		formats = append(formats, name)
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+// Position is a line and column in a message, both starting at 1. Columns
+// count code points.
+type Position struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// position returns the position of a byte offset in message
+func position(message string, offset int) Position {
+	if offset > len(message) {
+		offset = len(message)
+	}
+
+	before := message[:offset]
+	lineStart := strings.LastIndexByte(before, '\n') + 1
+
+	return Position{
+		Line:   strings.Count(before, "\n") + 1,
+		Column: utf8.RuneCountInString(before[lineStart:]) + 1,
+	}
+}
+
+// positions returns the start and end position of a span in message
+func positions(message string, span parser.Span) (Position, Position) {
+	return position(message, span.Start), position(message, span.End)
+}
@@ -0,0 +1,136 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/conventionalcommit/parser"
+	"github.com/conventionalcommit/parser/lint"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func testEntries() []Entry {
+	l := lint.New(parser.New(), lint.Conventional()...)
+	l.Add(lint.SeverityWarning, &lint.ImperativeMood{})
+
+	return []Entry{
+		{Source: "a1b2c3d", Result: l.Lint("feat(api): add x")},
+		{Source: "e4f5a6b", Result: l.Lint("Feat: Add ü.\n\nbody")},
+		{Source: ".git/COMMIT_EDITMSG", Result: l.Lint("fix: fixes \"y\"\n\nbody, 100%\n\nRefs: #1")},
+		{Source: "c7d8e9f", Result: l.Lint("fix: y\nbody")},
+	}
+}
+
+func TestReporters(t *testing.T) {
+	for _, format := range Formats() {
+		r, err := New(format)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var buf bytes.Buffer
+		if err := r.Report(&buf, testEntries()); err != nil {
+			t.Errorf("%s: Report() failed: %v", format, err)
+			continue
+		}
+
+		golden := filepath.Join("testdata", format+".golden")
+		if *update {
+			if err := os.WriteFile(golden, buf.Bytes(), 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		expected, err := os.ReadFile(golden)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != string(expected) {
+			t.Errorf("%s: Report() =\n%s\nexpected\n%s", format, buf.String(), expected)
+		}
+	}
+}
+
+func TestReportersWellFormed(t *testing.T) {
+	var buf bytes.Buffer
+
+	for _, format := range []string{"json", "sarif"} {
+		buf.Reset()
+		r, _ := New(format)
+		_ = r.Report(&buf, testEntries())
+		if !json.Valid(buf.Bytes()) {
+			t.Errorf("%s: invalid JSON", format)
+		}
+	}
+
+	for _, format := range []string{"junit", "checkstyle"} {
+		buf.Reset()
+		r, _ := New(format)
+		_ = r.Report(&buf, testEntries())
+
+		var v interface{}
+		if err := xml.Unmarshal(buf.Bytes(), &v); err != nil {
+			t.Errorf("%s: invalid XML: %v", format, err)
+		}
+	}
+}
+
+func TestRegister(t *testing.T) {
+	if _, err := New("html"); err == nil {
+		t.Error("New(html) should fail for unknown format")
+	}
+
+	Register("count", ReporterFunc(func(w io.Writer, entries []Entry) error {
+		_, err := fmt.Fprintln(w, len(entries))
+		return err
+	}))
+	defer delete(reporters, "count")
+
+	r, err := New("count")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	_ = r.Report(&buf, testEntries())
+	if buf.String() != "4\n" {
+		t.Errorf("custom reporter wrote %q", buf.String())
+	}
+}
+
+func TestPosition(t *testing.T) {
+	var cases = []struct {
+		message  string
+		offset   int
+		expected Position
+	}{
+		{"feat: x", 0, Position{1, 1}},
+		{"feat: x", 6, Position{1, 7}},
+		{"feat: ü x", 9, Position{1, 9}},
+		{"feat: x\n\nbody", 9, Position{3, 1}},
+		{"feat: x\n\nbody", 100, Position{3, 5}},
+	}
+
+	for _, tc := range cases {
+		if actual := position(tc.message, tc.offset); actual != tc.expected {
+			t.Errorf("position(%q, %d) = %v, expected %v", tc.message, tc.offset, actual, tc.expected)
+		}
+	}
+}
+
+func TestGitHubEscaping(t *testing.T) {
+	if actual := escapeGitHubData("100% done\nnext"); actual != "100%25 done%0Anext" {
+		t.Errorf("escapeGitHubData() = %q", actual)
+	}
+	if actual := escapeGitHubProperty("a:b,c"); actual != "a%3Ab%2Cc" {
+		t.Errorf("escapeGitHubProperty() = %q", actual)
+	}
+}
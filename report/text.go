@@ -0,0 +1,55 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Plain writes a line "source:line:column: severity: message [rule]" for every diagnostic
+func Plain(w io.Writer, entries []Entry) error {
+	for _, e := range entries {
+		for _, d := range e.Result.Diagnostics {
+			start := position(e.Result.Message, d.Span.Start)
+			if _, err := fmt.Fprintf(w, "%s:%d:%d: %s\n", e.Source, start.Line, start.Column, d); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// GitHub writes GitHub Actions workflow commands, which annotate the
+// diagnostics in the checks of a workflow run
+func GitHub(w io.Writer, entries []Entry) error {
+	for _, e := range entries {
+		for _, d := range e.Result.Diagnostics {
+			start, end := positions(e.Result.Message, d.Span)
+
+			props := []string{
+				"file=" + escapeGitHubProperty(e.Source),
+				fmt.Sprintf("line=%d", start.Line),
+				fmt.Sprintf("col=%d", start.Column),
+				fmt.Sprintf("endLine=%d", end.Line),
+				fmt.Sprintf("endColumn=%d", end.Column),
+				"title=" + escapeGitHubProperty(d.Rule),
+			}
+
+			_, err := fmt.Fprintf(w, "::%s %s::%s\n", d.Severity, strings.Join(props, ","), escapeGitHubData(d.Message))
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// escapeGitHubData escapes the message of a workflow command
+func escapeGitHubData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+// escapeGitHubProperty escapes a property value of a workflow command
+func escapeGitHubProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
@@ -0,0 +1,131 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/conventionalcommit/parser/lint"
+)
+
+// JUnit writes a JUnit XML report with a test case for every message. Messages
+// with errors fail, warnings are written to the output of the test case.
+func JUnit(w io.Writer, entries []Entry) error {
+	suite := junitSuite{
+		Name:  ToolName,
+		Tests: len(entries),
+	}
+
+	for _, e := range entries {
+		tc := junitCase{
+			Name:      e.Source,
+			ClassName: ToolName,
+		}
+
+		if errs := e.Result.Errors(); len(errs) > 0 {
+			suite.Failures++
+			tc.Failure = &junitFailure{
+				Message: fmt.Sprintf("lint errors: %d", len(errs)),
+				Type:    errs[0].Rule,
+				Text:    describe(e, errs),
+			}
+		}
+		if warnings := e.Result.Warnings(); len(warnings) > 0 {
+			tc.SystemOut = describe(e, warnings)
+		}
+
+		suite.Cases = append(suite.Cases, tc)
+	}
+
+	return writeXML(w, junitSuites{Suites: []junitSuite{suite}})
+}
+
+// Checkstyle writes a checkstyle XML report with a file element for every
+// message. The source of every error is prefixed with the tool name.
+func Checkstyle(w io.Writer, entries []Entry) error {
+	report := checkstyleReport{Version: "4.3"}
+
+	for _, e := range entries {
+		file := checkstyleFile{Name: e.Source}
+		for _, d := range e.Result.Diagnostics {
+			start := position(e.Result.Message, d.Span.Start)
+			file.Errors = append(file.Errors, checkstyleError{
+				Line:     start.Line,
+				Column:   start.Column,
+				Severity: d.Severity.String(),
+				Message:  d.Message,
+				Source:   ToolName + "." + d.Rule,
+			})
+		}
+		report.Files = append(report.Files, file)
+	}
+
+	return writeXML(w, report)
+}
+
+// describe lists the diagnostics in the plain format
+func describe(e Entry, diags []lint.Diagnostic) string {
+	var b strings.Builder
+	_ = Plain(&b, []Entry{{Source: e.Source, Result: &lint.Result{Message: e.Result.Message, Diagnostics: diags}}})
+	return b.String()
+}
+
+func writeXML(w io.Writer, v interface{}) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+type junitSuites struct {
+	XMLName xml.Name     `xml:"testsuites"`
+	Suites  []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name     string      `xml:"name,attr"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Cases    []junitCase `xml:"testcase"`
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+type checkstyleReport struct {
+	XMLName xml.Name         `xml:"checkstyle"`
+	Version string           `xml:"version,attr"`
+	Files   []checkstyleFile `xml:"file"`
+}
+
+type checkstyleFile struct {
+	Name   string            `xml:"name,attr"`
+	Errors []checkstyleError `xml:"error"`
+}
+
+type checkstyleError struct {
+	Line     int    `xml:"line,attr"`
+	Column   int    `xml:"column,attr"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+}
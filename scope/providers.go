@@ -0,0 +1,299 @@
+package scope
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// majorVersionSuffix matches the major version element of Go module paths, like "/v2"
+var majorVersionSuffix = regexp.MustCompile(`/v[0-9]+$`)
+
+// errNoModulePath is returned for go.mod files without module directive
+var errNoModulePath = "%s has no module directive"
+
+// skippedDirs are never scopes, besides hidden directories
+var skippedDirs = map[string]bool{
+	"node_modules": true,
+	"vendor":       true,
+	"testdata":     true,
+}
+
+// TopLevelDirs provides the directories at the root of the working tree,
+// without hidden directories, node_modules, vendor and testdata
+type TopLevelDirs struct{}
+
+// Scopes returns the names of the top-level directories
+func (TopLevelDirs) Scopes(root string) ([]string, error) {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return nil, err
+	}
+
+	var scopes []string
+	for _, e := range entries {
+		if e.IsDir() && !isSkippedDir(e.Name()) {
+			scopes = append(scopes, e.Name())
+		}
+	}
+	return scopes, nil
+}
+
+// GoModules provides the last element of the module paths of the Go modules
+// used by go.work, or of all go.mod files if there is no go.work. Major
+// version suffixes are ignored, "example.com/tool/v2" provides "tool".
+type GoModules struct{}
+
+// Scopes returns the names of the Go modules
+func (GoModules) Scopes(root string) ([]string, error) {
+	dirs, err := goWorkDirs(root)
+	if err != nil {
+		return nil, err
+	}
+
+	if dirs == nil {
+		err = walkDirs(root, func(dir string) {
+			if fileExists(filepath.Join(dir, "go.mod")) {
+				dirs = append(dirs, dir)
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var scopes []string
+	for _, dir := range dirs {
+		modPath, err := goModulePath(filepath.Join(dir, "go.mod"))
+		if err != nil {
+			return nil, err
+		}
+		scopes = append(scopes, path.Base(majorVersionSuffix.ReplaceAllString(modPath, "")))
+	}
+	return scopes, nil
+}
+
+// GoPackages provides the slash separated paths of the directories with Go
+// files relative to root, like "internal/cache". The root package is not a
+// scope.
+type GoPackages struct{}
+
+// Scopes returns the paths of the Go packages
+func (GoPackages) Scopes(root string) ([]string, error) {
+	var scopes []string
+
+	err := walkDirs(root, func(dir string) {
+		rel, err := filepath.Rel(root, dir)
+		if err != nil || rel == "." {
+			return
+		}
+
+		matches, _ := filepath.Glob(filepath.Join(dir, "*.go"))
+		if len(matches) > 0 {
+			scopes = append(scopes, filepath.ToSlash(rel))
+		}
+	})
+
+	return scopes, err
+}
+
+// Workspaces provides the packages of npm, yarn and pnpm workspaces, listed
+// in the "workspaces" of package.json or the "packages" of
+// pnpm-workspace.yaml. The scope is the package name without npm scope,
+// "@acme/ui" provides "ui", or the directory name for unnamed packages.
+type Workspaces struct{}
+
+// Scopes returns the names of the workspace packages
+func (Workspaces) Scopes(root string) ([]string, error) {
+	patterns, err := workspacePatterns(root)
+	if err != nil {
+		return nil, err
+	}
+
+	var include, exclude []string
+	for _, p := range patterns {
+		if strings.HasPrefix(p, "!") {
+			exclude = append(exclude, strings.TrimPrefix(p, "!"))
+		} else {
+			include = append(include, p)
+		}
+	}
+
+	var scopes []string
+	var parseErr error
+	err = walkDirs(root, func(dir string) {
+		rel, err := filepath.Rel(root, dir)
+		if err != nil || rel == "." || parseErr != nil {
+			return
+		}
+		rel = filepath.ToSlash(rel)
+
+		if !matchAny(include, rel) || matchAny(exclude, rel) {
+			return
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, "package.json"))
+		if err != nil {
+			return
+		}
+
+		var pkg struct {
+			Name string `json:"name"`
+		}
+		if err := json.Unmarshal(data, &pkg); err != nil {
+			parseErr = err
+			return
+		}
+
+		name := pkg.Name
+		if i := strings.LastIndexByte(name, '/'); i >= 0 {
+			name = name[i+1:]
+		}
+		if name == "" {
+			name = path.Base(rel)
+		}
+		scopes = append(scopes, name)
+	})
+	if parseErr != nil {
+		return nil, parseErr
+	}
+
+	return scopes, err
+}
+
+// workspacePatterns returns the workspace globs of package.json and pnpm-workspace.yaml
+func workspacePatterns(root string) ([]string, error) {
+	var patterns []string
+
+	if data, err := os.ReadFile(filepath.Join(root, "package.json")); err == nil {
+		var pkg struct {
+			Workspaces json.RawMessage `json:"workspaces"`
+		}
+		if err := json.Unmarshal(data, &pkg); err != nil {
+			return nil, err
+		}
+
+		var list []string
+		var object struct {
+			Packages []string `json:"packages"`
+		}
+		if json.Unmarshal(pkg.Workspaces, &list) == nil {
+			patterns = append(patterns, list...)
+		} else if json.Unmarshal(pkg.Workspaces, &object) == nil {
+			patterns = append(patterns, object.Packages...)
+		}
+	}
+
+	if data, err := os.ReadFile(filepath.Join(root, "pnpm-workspace.yaml")); err == nil {
+		var ws struct {
+			Packages []string `yaml:"packages"`
+		}
+		if err := yaml.Unmarshal(data, &ws); err != nil {
+			return nil, err
+		}
+		patterns = append(patterns, ws.Packages...)
+	}
+
+	for i, p := range patterns {
+		patterns[i] = strings.TrimSuffix(strings.TrimPrefix(p, "./"), "/")
+	}
+	return patterns, nil
+}
+
+// goWorkDirs returns the directories of the use directives of go.work, or
+// nil if there is no go.work
+func goWorkDirs(root string) ([]string, error) {
+	data, err := os.ReadFile(filepath.Join(root, "go.work"))
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	dirs := []string{}
+	inUse := false
+	for _, line := range strings.Split(string(data), "\n") {
+		if i := strings.Index(line, "//"); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+
+		switch {
+		case len(fields) == 0:
+		case inUse && fields[0] == ")":
+			inUse = false
+		case inUse:
+			dirs = append(dirs, filepath.Join(root, unquote(fields[0])))
+		case fields[0] == "use" && len(fields) > 1 && fields[1] == "(":
+			inUse = true
+		case fields[0] == "use" && len(fields) > 1:
+			dirs = append(dirs, filepath.Join(root, unquote(fields[1])))
+		}
+	}
+
+	return dirs, nil
+}
+
+// goModulePath returns the module path of a go.mod file, and an error if it
+// has none
+func goModulePath(file string) (string, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return "", err
+	}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) > 1 && fields[0] == "module" {
+			return unquote(fields[1]), nil
+		}
+	}
+	return "", fmt.Errorf(errNoModulePath, file)
+}
+
+// walkDirs calls fn for root and every directory below it, in lexical
+// order, skipping hidden and skipped directories
+func walkDirs(root string, fn func(dir string)) error {
+	var dirs []string
+
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if p != root && isSkippedDir(d.Name()) {
+			return filepath.SkipDir
+		}
+		dirs = append(dirs, p)
+		return nil
+	})
+
+	sort.Strings(dirs)
+	for _, dir := range dirs {
+		fn(dir)
+	}
+	return err
+}
+
+func isSkippedDir(name string) bool {
+	return strings.HasPrefix(name, ".") || skippedDirs[name]
+}
+
+func fileExists(name string) bool {
+	_, err := os.Stat(name)
+	return err == nil
+}
+
+func unquote(s string) string {
+	return strings.Trim(s, "\"`")
+}
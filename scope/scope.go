@@ -0,0 +1,119 @@
+// Package scope discovers the scopes allowed in commit messages from the
+// layout of a repository, like its directories, Go modules or npm workspaces
+package scope
+
+import (
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Provider discovers scopes in the working tree at root
+type Provider interface {
+	Scopes(root string) ([]string, error)
+}
+
+// Discover returns the sorted scopes of all providers, without duplicates
+func Discover(root string, providers ...Provider) ([]string, error) {
+	seen := map[string]bool{}
+	var scopes []string
+
+	for _, p := range providers {
+		found, err := p.Scopes(root)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range found {
+			if s != "" && !seen[s] {
+				seen[s] = true
+				scopes = append(scopes, s)
+			}
+		}
+	}
+
+	sort.Strings(scopes)
+	return scopes, nil
+}
+
+// Static provides a fixed list of scopes, like scopes not tied to the layout
+type Static []string
+
+// Scopes returns the scopes of the list
+func (s Static) Scopes(string) ([]string, error) {
+	return s, nil
+}
+
+// Filter limits the scopes of Provider to those matching one of Include, if
+// any, and none of Exclude. Patterns are globs, "*" matches within a path
+// segment, "**" across segments, like "internal/**".
+type Filter struct {
+	Provider Provider
+	Include  []string
+	Exclude  []string
+}
+
+// Scopes returns the filtered scopes of the provider
+func (f Filter) Scopes(root string) ([]string, error) {
+	scopes, err := f.Provider.Scopes(root)
+	if err != nil {
+		return nil, err
+	}
+
+	var filtered []string
+	for _, s := range scopes {
+		if len(f.Include) > 0 && !matchAny(f.Include, s) {
+			continue
+		}
+		if matchAny(f.Exclude, s) {
+			continue
+		}
+		filtered = append(filtered, s)
+	}
+	return filtered, nil
+}
+
+func matchAny(patterns []string, name string) bool {
+	for _, p := range patterns {
+		if Match(p, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// Match reports whether name matches the glob pattern. Besides the syntax of
+// path.Match, "**" matches any number of path segments.
+func Match(pattern, name string) bool {
+	if !strings.Contains(pattern, "**") {
+		ok, _ := path.Match(pattern, name)
+		return ok
+	}
+
+	re, err := regexp.Compile("^" + globToRegexp(pattern) + "$")
+	return err == nil && re.MatchString(name)
+}
+
+// globToRegexp converts a glob with "**" to a regular expression
+func globToRegexp(pattern string) string {
+	var b strings.Builder
+
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			b.WriteString("(.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case c == '*':
+			b.WriteString("[^/]*")
+		case c == '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,121 @@
+package scope
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestProviders(t *testing.T) {
+	var cases = []struct {
+		root     string
+		provider Provider
+		expected []string
+	}{
+		{"gowork", TopLevelDirs{}, []string{"api", "docs", "internal", "tools"}},
+		{"gowork", GoModules{}, []string{"api", "cli"}},
+		{"gomods", GoModules{}, []string{"root", "sub"}},
+		{"gowork", GoPackages{}, []string{"api", "internal/cache", "tools/cli"}},
+		{"npm", Workspaces{}, []string{"core", "ui", "unnamed"}},
+		{"pnpm", Workspaces{}, []string{"admin", "web"}},
+		{"gowork", Workspaces{}, nil},
+		{"gowork", Filter{Provider: GoPackages{}, Exclude: []string{"internal/**"}}, []string{"api", "tools/cli"}},
+		{"gowork", Filter{Provider: TopLevelDirs{}, Include: []string{"a*", "d?cs"}}, []string{"api", "docs"}},
+	}
+
+	for i, tc := range cases {
+		actual, err := Discover(filepath.Join("testdata", tc.root), tc.provider)
+		if err != nil {
+			t.Errorf("case#%d: Discover(%s) failed: %v", i, tc.root, err)
+			continue
+		}
+		if !reflect.DeepEqual(actual, tc.expected) {
+			t.Errorf("case#%d: Discover(%s) = %q, expected %q", i, tc.root, actual, tc.expected)
+		}
+	}
+}
+
+func TestWorkspacesInvalidPackage(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"package.json":             `{"workspaces": ["packages/*"]}`,
+		"packages/ui/package.json": `{"name": `,
+	}
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := (Workspaces{}).Scopes(root); err == nil {
+		t.Error("Scopes() passed without error for an invalid package.json")
+	}
+}
+
+func TestGoModulesWithoutModulePath(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "go.mod"), []byte("go 1.17\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := (GoModules{}).Scopes(root); err == nil {
+		t.Error("Scopes() passed without error for a go.mod without module directive")
+	}
+}
+
+func TestDiscoverMerges(t *testing.T) {
+	actual, err := Discover(filepath.Join("testdata", "gowork"), TopLevelDirs{}, GoModules{}, Static{"deps", "api"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"api", "cli", "deps", "docs", "internal", "tools"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Discover() = %q, expected %q", actual, expected)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	var cases = []struct {
+		pattern, name string
+		expected      bool
+	}{
+		{"api", "api", true},
+		{"a*", "api", true},
+		{"a*", "api/v1", false},
+		{"internal/**", "internal/cache/lru", true},
+		{"**/cache", "internal/cache", true},
+		{"**/cache", "cache", true},
+		{"**", "anything/at/all", true},
+	}
+
+	for _, tc := range cases {
+		if actual := Match(tc.pattern, tc.name); actual != tc.expected {
+			t.Errorf("Match(%q, %q) = %v, expected %v", tc.pattern, tc.name, actual, tc.expected)
+		}
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	scopes := []string{"api", "cli", "user-api", "internal/cache", "parser"}
+
+	var cases = map[string][]string{
+		"UserAPI":       {"user-api"},
+		"pasrer":        {"parser"},
+		"apo":           {"api"},
+		"internal/cahe": {"internal/cache"},
+		"xyz":           nil,
+		"cl":            {"cli"},
+	}
+
+	for name, expected := range cases {
+		if actual := Suggest(name, scopes); !reflect.DeepEqual(actual, expected) {
+			t.Errorf("Suggest(%q) = %q, expected %q", name, actual, expected)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package scope
+
+import (
+	"sort"
+	"unicode/utf8"
+
+	"github.com/conventionalcommit/parser/casing"
+)
+
+// Suggest returns the candidates closest to name, to suggest them for an
+// unknown scope. Names are compared in kebab case, so "UserAPI" suggests
+// "user-api". Only candidates within an edit distance of a third of the
+// length of name, but at least one, are suggested.
+func Suggest(name string, candidates []string) []string {
+	normalized := casing.Convert(name, casing.Kebab)
+
+	limit := utf8.RuneCountInString(normalized) / 3
+	if limit < 1 {
+		limit = 1
+	}
+
+	best := limit + 1
+	var suggestions []string
+	for _, c := range candidates {
+		d := distance(normalized, casing.Convert(c, casing.Kebab))
+		switch {
+		case d < best:
+			best = d
+			suggestions = []string{c}
+		case d == best:
+			suggestions = append(suggestions, c)
+		}
+	}
+
+	sort.Strings(suggestions)
+	return suggestions
+}
+
+// distance returns the Levenshtein distance of a and b in runes
+func distance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = minInt(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+
+	return prev[len(rb)]
+}
+
+func minInt(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
@@ -0,0 +1,3 @@
+module example.com/repo/api
+
+go 1.21
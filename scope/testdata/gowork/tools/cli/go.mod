@@ -0,0 +1,3 @@
+module "example.com/cli/v2"
+
+go 1.21
@@ -0,0 +1,221 @@
+package semver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/conventionalcommit/parser"
+)
+
+var (
+	errPrereleaseInvalid = "invalid pre-release identifier %q: %w"
+	errBuildInvalid      = "invalid build metadata %q: %w"
+)
+
+// Option configures the version bump calculation
+type Option func(*bumper)
+
+type bumper struct {
+	registry *parser.TypeRegistry
+	// overrides are the WithImpact options in order, resolved into impacts
+	// once the registry is known
+	overrides  []impactOverride
+	impacts    map[string]parser.Impact
+	prerelease []string
+	build      []string
+	err        error
+}
+
+type impactOverride struct {
+	commitType string
+	impact     parser.Impact
+}
+
+// WithTypeRegistry sets the registry used to map commit types to their
+// impact. parser.DefaultTypeRegistry is used by default.
+func WithTypeRegistry(r *parser.TypeRegistry) Option {
+	return func(b *bumper) {
+		b.registry = r
+	}
+}
+
+// WithImpact overrides the impact of a commit type and its aliases. The
+// type may be given by its name or an alias, which are resolved using the
+// type registry. If a type is overridden twice, the last option wins.
+func WithImpact(commitType string, impact parser.Impact) Option {
+	return func(b *bumper) {
+		b.overrides = append(b.overrides, impactOverride{commitType, impact})
+	}
+}
+
+// WithPrerelease makes the next version a pre-release with the given
+// identifier, like "rc" for 1.2.0-rc.0 or "alpha.beta" for
+// 1.2.0-alpha.beta.0. Next fails if id is not a valid pre-release.
+func WithPrerelease(id string) Option {
+	return func(b *bumper) {
+		ids, err := parseIdentifiers(id, true)
+		if err != nil {
+			b.err = fmt.Errorf(errPrereleaseInvalid, id, err)
+			return
+		}
+		b.prerelease = ids
+	}
+}
+
+// WithBuild sets the build metadata of the next version. Next fails if meta
+// is not valid build metadata.
+func WithBuild(meta string) Option {
+	return func(b *bumper) {
+		ids, err := parseIdentifiers(meta, false)
+		if err != nil {
+			b.err = fmt.Errorf(errBuildInvalid, meta, err)
+			return
+		}
+		b.build = ids
+	}
+}
+
+// Next returns the version following current for the given commits, and the
+// commits with the highest impact that drove the bump. If no commit requires
+// a release, current is returned unchanged.
+//
+// While the major version is zero, breaking changes bump the minor version.
+// If current is a pre-release, the bump is relative to the release it
+// precedes, so 1.2.0-rc.1 with a fix becomes 1.2.0, or 1.2.0-rc.2 when
+// a pre-release identifier "rc" is given.
+//
+// An error is returned if an option is invalid.
+func Next(current Version, commits []*parser.Commit, opts ...Option) (Version, []*parser.Commit, error) {
+	b := &bumper{
+		impacts: make(map[string]parser.Impact),
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	if b.err != nil {
+		return current, nil, b.err
+	}
+	if b.registry == nil {
+		b.registry = parser.DefaultTypeRegistry()
+	}
+	for _, o := range b.overrides {
+		name := o.commitType
+		if t, ok := b.registry.Lookup(name); ok {
+			name = t.Name
+		}
+		b.impacts[strings.ToLower(name)] = o.impact
+	}
+
+	impact := parser.ImpactNone
+	var drivers []*parser.Commit
+
+	for _, c := range commits {
+		i := b.impact(c)
+		if i == parser.ImpactNone || i < impact {
+			continue
+		}
+		if i > impact {
+			impact = i
+			drivers = nil
+		}
+		drivers = append(drivers, c)
+	}
+
+	if impact == parser.ImpactNone {
+		return current, nil, nil
+	}
+
+	// breaking changes are allowed anytime before 1.0.0
+	if current.Major == 0 && impact == parser.ImpactMajor {
+		impact = parser.ImpactMinor
+	}
+
+	next := bump(current, impact)
+
+	if b.prerelease != nil {
+		next.Prerelease = nextPrerelease(current, next, b.prerelease)
+	}
+
+	if b.build != nil {
+		next.Build = b.build
+	}
+
+	return next, drivers, nil
+}
+
+// Impact returns the impact of the commit
+func (b *bumper) impact(c *parser.Commit) parser.Impact {
+	if c.IsBreakingChange() {
+		return parser.ImpactMajor
+	}
+
+	name := c.Type()
+	if t, ok := b.registry.Lookup(name); ok {
+		name = t.Name
+	}
+
+	if i, ok := b.impacts[strings.ToLower(name)]; ok {
+		return i
+	}
+
+	return b.registry.Impact(c)
+}
+
+// bump returns the release version for the impact
+func bump(v Version, impact parser.Impact) Version {
+	release := v.Release()
+
+	// a pre-release already carries the bump of the release it precedes
+	if v.IsPrerelease() && impact <= releaseImpact(release) {
+		return release
+	}
+
+	switch impact {
+	case parser.ImpactMajor:
+		return Version{Major: release.Major + 1}
+	case parser.ImpactMinor:
+		return Version{Major: release.Major, Minor: release.Minor + 1}
+	default:
+		return Version{Major: release.Major, Minor: release.Minor, Patch: release.Patch + 1}
+	}
+}
+
+// releaseImpact returns the highest impact a release version can carry
+// relative to its predecessor
+func releaseImpact(v Version) parser.Impact {
+	switch {
+	case v.Minor == 0 && v.Patch == 0 && v.Major > 0:
+		return parser.ImpactMajor
+	case v.Patch == 0:
+		return parser.ImpactMinor
+	default:
+		return parser.ImpactPatch
+	}
+}
+
+// nextPrerelease returns the pre-release identifiers of next. The counter
+// is incremented if current is a pre-release of the same version starting
+// with ids.
+func nextPrerelease(current, next Version, ids []string) []string {
+	counter := 0
+
+	if current.Release().Compare(next) == 0 && len(current.Prerelease) > len(ids) && hasPrefix(current.Prerelease, ids) {
+		last := current.Prerelease[len(current.Prerelease)-1]
+		if n, err := strconv.Atoi(last); err == nil {
+			counter = n + 1
+		}
+	}
+
+	return append(append([]string(nil), ids...), strconv.Itoa(counter))
+}
+
+// hasPrefix reports whether the identifiers start with prefix
+func hasPrefix(ids, prefix []string) bool {
+	for i, id := range prefix {
+		if ids[i] != id {
+			return false
+		}
+	}
+	return true
+}
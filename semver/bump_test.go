@@ -0,0 +1,87 @@
+package semver
+
+import (
+	"testing"
+
+	"github.com/conventionalcommit/parser"
+)
+
+func TestNext(t *testing.T) {
+	var cases = []struct {
+		name     string
+		current  string
+		commits  []string
+		opts     []Option
+		expected string
+		drivers  int
+	}{
+		{"no commits", "1.2.3", nil, nil, "1.2.3", 0},
+		{"no release", "1.2.3", []string{"docs: x", "chore: y"}, nil, "1.2.3", 0},
+		{"patch", "1.2.3", []string{"fix: x", "docs: y"}, nil, "1.2.4", 1},
+		{"minor", "1.2.3", []string{"fix: x", "feat: y", "feature: z"}, nil, "1.3.0", 2},
+		{"major", "1.2.3", []string{"feat: x", "fix!: y"}, nil, "2.0.0", 1},
+		{"major footer", "1.2.3", []string{"fix: x\n\nBREAKING CHANGE: y"}, nil, "2.0.0", 1},
+		{"pre 1.0 breaking", "0.2.3", []string{"feat!: x"}, nil, "0.3.0", 1},
+		{"pre 1.0 feature", "0.2.3", []string{"feat: x"}, nil, "0.3.0", 1},
+		{"drop build metadata", "1.2.3+abc", []string{"fix: x"}, nil, "1.2.4", 1},
+		{"build metadata", "1.2.3", []string{"fix: x"}, []Option{WithBuild("sha.abc")}, "1.2.4+sha.abc", 1},
+		{"impact override", "1.2.3", []string{"docs: x"}, []Option{WithImpact("docs", parser.ImpactPatch)}, "1.2.4", 1},
+		{"impact override alias", "1.2.3", []string{"feature: x"}, []Option{WithImpact("feat", parser.ImpactPatch)}, "1.2.4", 1},
+		{"impact override by alias", "1.2.3", []string{"feat: x"}, []Option{WithImpact("feature", parser.ImpactPatch)}, "1.2.4", 1},
+		{"impact override last wins", "1.2.3", []string{"feat: x"}, []Option{WithImpact("Feature", parser.ImpactPatch), WithImpact("feat", parser.ImpactMajor)}, "2.0.0", 1},
+		{"new prerelease", "1.2.3", []string{"feat: x"}, []Option{WithPrerelease("rc")}, "1.3.0-rc.0", 1},
+		{"next prerelease", "1.3.0-rc.0", []string{"fix: x"}, []Option{WithPrerelease("rc")}, "1.3.0-rc.1", 1},
+		{"prerelease id change", "1.3.0-beta.4", []string{"fix: x"}, []Option{WithPrerelease("rc")}, "1.3.0-rc.0", 1},
+		{"prerelease escalates", "1.2.4-rc.2", []string{"feat: x"}, []Option{WithPrerelease("rc")}, "1.3.0-rc.0", 1},
+		{"next dotted prerelease", "1.3.0-alpha.beta.1", []string{"fix: x"}, []Option{WithPrerelease("alpha.beta")}, "1.3.0-alpha.beta.2", 1},
+		{"dotted prerelease id change", "1.3.0-alpha.1", []string{"fix: x"}, []Option{WithPrerelease("alpha.beta")}, "1.3.0-alpha.beta.0", 1},
+		{"prerelease graduates", "1.3.0-rc.1", []string{"fix: x"}, nil, "1.3.0", 1},
+		{"prerelease graduates major", "2.0.0-rc.1", []string{"feat!: x"}, nil, "2.0.0", 1},
+		{"prerelease escalates major", "1.3.0-rc.1", []string{"feat!: x"}, nil, "2.0.0", 1},
+	}
+
+	p := parser.New()
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(innerT *testing.T) {
+			var commits []*parser.Commit
+			for _, msg := range tc.commits {
+				c, err := p.Parse(msg)
+				if err != nil {
+					innerT.Fatal(err)
+				}
+				commits = append(commits, c)
+			}
+
+			next, drivers, err := Next(MustParse(tc.current), commits, tc.opts...)
+			if err != nil {
+				innerT.Fatal(err)
+			}
+			if next.String() != tc.expected {
+				innerT.Errorf("Next(%s) = %s, expected %s", tc.current, next, tc.expected)
+			}
+			if len(drivers) != tc.drivers {
+				innerT.Errorf("expected %d commits driving the bump, got %d", tc.drivers, len(drivers))
+			}
+		})
+	}
+}
+
+func TestNextInvalidOptions(t *testing.T) {
+	var cases = []Option{
+		WithPrerelease("rc..1"),
+		WithPrerelease("rc.01"),
+		WithPrerelease("rc_1"),
+		WithPrerelease(""),
+		WithBuild("sha/abc"),
+		WithBuild(""),
+	}
+
+	commits := []*parser.Commit{}
+	for i, opt := range cases {
+		if _, _, err := Next(MustParse("1.2.3"), commits, opt); err == nil {
+			t.Errorf("case#%d: Next() passed without error", i)
+		}
+	}
+}
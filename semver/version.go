@@ -0,0 +1,203 @@
+// Package semver calculates semantic versions from conventional commits
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	errVersionEmpty = errors.New("version is empty")
+
+	errVersionInvalid    = "invalid version %q: %s"
+	errIdentifierInvalid = "invalid identifier %q"
+)
+
+// Version represents a semantic version, see https://semver.org/spec/v2.0.0.html
+type Version struct {
+	Major, Minor, Patch uint64
+
+	// Prerelease holds the dot separated pre-release identifiers, like ["rc", "1"]
+	Prerelease []string
+	// Build holds the dot separated build metadata identifiers
+	Build []string
+}
+
+// Parse parses a semantic version. A leading "v" is allowed.
+func Parse(s string) (Version, error) {
+	var v Version
+
+	if s == "" {
+		return v, errVersionEmpty
+	}
+	str := strings.TrimPrefix(s, "v")
+
+	if i := strings.IndexByte(str, '+'); i >= 0 {
+		build, err := parseIdentifiers(str[i+1:], false)
+		if err != nil {
+			return v, fmt.Errorf(errVersionInvalid, s, err)
+		}
+		v.Build = build
+		str = str[:i]
+	}
+
+	if i := strings.IndexByte(str, '-'); i >= 0 {
+		pre, err := parseIdentifiers(str[i+1:], true)
+		if err != nil {
+			return v, fmt.Errorf(errVersionInvalid, s, err)
+		}
+		v.Prerelease = pre
+		str = str[:i]
+	}
+
+	parts := strings.Split(str, ".")
+	if len(parts) != 3 {
+		return v, fmt.Errorf(errVersionInvalid, s, "expected major.minor.patch")
+	}
+
+	nums := make([]uint64, 3)
+	for i, part := range parts {
+		if !isNumeric(part) || (len(part) > 1 && part[0] == '0') {
+			return v, fmt.Errorf(errVersionInvalid, s, "invalid number "+strconv.Quote(part))
+		}
+		n, err := strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			return v, fmt.Errorf(errVersionInvalid, s, err)
+		}
+		nums[i] = n
+	}
+	v.Major, v.Minor, v.Patch = nums[0], nums[1], nums[2]
+
+	return v, nil
+}
+
+// MustParse is like Parse but panics if the version cannot be parsed
+func MustParse(s string) Version {
+	v, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// String returns the version without a "v" prefix
+func (v Version) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if len(v.Prerelease) > 0 {
+		b.WriteString("-" + strings.Join(v.Prerelease, "."))
+	}
+	if len(v.Build) > 0 {
+		b.WriteString("+" + strings.Join(v.Build, "."))
+	}
+	return b.String()
+}
+
+// IsPrerelease returns true if the version has pre-release identifiers
+func (v Version) IsPrerelease() bool {
+	return len(v.Prerelease) > 0
+}
+
+// Release returns the version without pre-release identifiers and build metadata
+func (v Version) Release() Version {
+	return Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch}
+}
+
+// Compare returns -1, 0 or 1 if v has lower, equal or higher precedence than o.
+// Build metadata is ignored.
+func (v Version) Compare(o Version) int {
+	if c := compareUint(v.Major, o.Major); c != 0 {
+		return c
+	}
+	if c := compareUint(v.Minor, o.Minor); c != 0 {
+		return c
+	}
+	if c := compareUint(v.Patch, o.Patch); c != 0 {
+		return c
+	}
+
+	// a version without pre-release identifiers has higher precedence
+	switch {
+	case len(v.Prerelease) == 0 && len(o.Prerelease) == 0:
+		return 0
+	case len(v.Prerelease) == 0:
+		return 1
+	case len(o.Prerelease) == 0:
+		return -1
+	}
+
+	for i := 0; i < len(v.Prerelease) && i < len(o.Prerelease); i++ {
+		if c := compareIdentifier(v.Prerelease[i], o.Prerelease[i]); c != 0 {
+			return c
+		}
+	}
+	return compareUint(uint64(len(v.Prerelease)), uint64(len(o.Prerelease)))
+}
+
+func compareIdentifier(a, b string) int {
+	aNum, bNum := isNumeric(a), isNumeric(b)
+	switch {
+	case aNum && bNum:
+		if c := compareUint(uint64(len(a)), uint64(len(b))); c != 0 {
+			return c
+		}
+		return strings.Compare(a, b)
+	case aNum:
+		return -1
+	case bNum:
+		return 1
+	default:
+		return strings.Compare(a, b)
+	}
+}
+
+func compareUint(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func parseIdentifiers(s string, prerelease bool) ([]string, error) {
+	ids := strings.Split(s, ".")
+	for _, id := range ids {
+		if !isValidIdentifier(id) {
+			return nil, fmt.Errorf(errIdentifierInvalid, id)
+		}
+		// numeric pre-release identifiers must not include leading zeroes
+		if prerelease && isNumeric(id) && len(id) > 1 && id[0] == '0' {
+			return nil, fmt.Errorf(errIdentifierInvalid, id)
+		}
+	}
+	return ids, nil
+}
+
+func isValidIdentifier(id string) bool {
+	if id == "" {
+		return false
+	}
+	for _, r := range id {
+		if !(r == '-' || ('0' <= r && r <= '9') || ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z')) {
+			return false
+		}
+	}
+	return true
+}
+
+func isNumeric(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,74 @@
+package semver
+
+import (
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	var validCases = map[string]string{
+		"1.2.3":                  "1.2.3",
+		"v1.2.3":                 "1.2.3",
+		"0.0.0":                  "0.0.0",
+		"1.2.3-rc.1":             "1.2.3-rc.1",
+		"1.2.3-alpha-1.0a+exp.1": "1.2.3-alpha-1.0a+exp.1",
+		"1.2.3+20210101":         "1.2.3+20210101",
+	}
+
+	for input, expected := range validCases {
+		v, err := Parse(input)
+		if err != nil {
+			t.Errorf("Parse(%q) failed: %v", input, err)
+			continue
+		}
+		if v.String() != expected {
+			t.Errorf("Parse(%q) = %s, expected %s", input, v, expected)
+		}
+	}
+
+	var invalidCases = []string{
+		"",
+		"1.2",
+		"1.2.3.4",
+		"01.2.3",
+		"1.2.x",
+		"1.2.3-",
+		"1.2.3-rc..1",
+		"1.2.3-01",
+		"1.2.3+",
+		"1.2.3-rc_1",
+	}
+
+	for _, input := range invalidCases {
+		if _, err := Parse(input); err == nil {
+			t.Errorf("Parse(%q) passed without error", input)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	// in ascending order of precedence, from https://semver.org/#spec-item-11
+	var ordered = []string{
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"1.0.1",
+		"1.1.0",
+		"2.0.0",
+	}
+
+	for i := 0; i < len(ordered)-1; i++ {
+		a, b := MustParse(ordered[i]), MustParse(ordered[i+1])
+		if a.Compare(b) != -1 || b.Compare(a) != 1 {
+			t.Errorf("expected %s < %s", a, b)
+		}
+	}
+
+	if MustParse("1.0.0+a").Compare(MustParse("1.0.0+b")) != 0 {
+		t.Error("build metadata must be ignored")
+	}
+}
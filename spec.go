@@ -0,0 +1,161 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Mode selects which rules the parser enforces on top of the reference grammar
+type Mode int
+
+const (
+	// ModeLenient accepts every message allowed by the reference grammar. This is the default.
+	ModeLenient Mode = iota
+	// ModeStrictV100 enforces every rule of Conventional Commits 1.0.0
+	ModeStrictV100
+)
+
+var (
+	errTypeEmpty          = errors.New("type is empty")
+	errDescEmpty          = errors.New("description is empty")
+	errDescLeadingSpace   = errors.New("description must immediately follow ': '")
+	errBreakingTokenCase  = "footer: breaking change token must be uppercase, got %q"
+	errFooterValueEmpty   = "footer: missing value for token %q"
+	errBreakingSeparator  = "footer: breaking change token must be followed by ': ', got %q"
+	errFooterSeparator    = "footer: invalid separator %q after token %q"
+	errStrictTypeChar     = "type: invalid character '%c', type must be a noun"
+	errStrictScopeChar    = "scope: invalid character '%c', scope must be a noun"
+	errUnknownParserMode  = "unknown parser mode %d"
+	errUnknownSpecVersion = "unknown specification version %q"
+)
+
+// breakingSeparatorLine matches lines starting with a breaking change token
+// and a separator, which is not a footer unless the separator is ": "
+var breakingSeparatorLine = regexp.MustCompile(`(?m)^(?:BREAKING CHANGE|BREAKING-CHANGE)([ \t]?[^\w\s][ \t]?)`)
+
+// WithMode sets the mode of the parser
+func WithMode(m Mode) Option {
+	return func(p *Parser) {
+		p.mode = m
+	}
+}
+
+// ModeForSpec returns the strict mode for the given Conventional Commits specification version, like "1.0.0"
+func ModeForSpec(version string) (Mode, error) {
+	switch strings.TrimPrefix(version, "v") {
+	case "1.0.0":
+		return ModeStrictV100, nil
+	default:
+		return ModeLenient, fmt.Errorf(errUnknownSpecVersion, version)
+	}
+}
+
+// Spec returns the specification version enforced by the mode, empty for ModeLenient
+func (m Mode) Spec() string {
+	switch m {
+	case ModeStrictV100:
+		return "1.0.0"
+	default:
+		return ""
+	}
+}
+
+// String returns the name of the mode
+func (m Mode) String() string {
+	switch m {
+	case ModeLenient:
+		return "lenient"
+	case ModeStrictV100:
+		return "strict-v1.0.0"
+	default:
+		return fmt.Sprintf("Mode(%d)", int(m))
+	}
+}
+
+// validate checks the rules of the mode that the lexer does not enforce
+func (m Mode) validate(c *Commit) error {
+	switch m {
+	case ModeLenient:
+		return nil
+	case ModeStrictV100:
+		return validateV100(c)
+	default:
+		return newError(fmt.Errorf(errUnknownParserMode, int(m)), Span{})
+	}
+}
+
+// validateV100 checks the rules of https://www.conventionalcommits.org/en/v1.0.0/#specification
+func validateV100(c *Commit) error {
+	// 1. Commits MUST be prefixed with a type, which consists of a noun
+	if c.commitType == "" {
+		return newError(errTypeEmpty, c.typeSpan)
+	}
+	for i, r := range c.commitType {
+		if !isStrictTypeChar(r) {
+			return newError(fmt.Errorf(errStrictTypeChar, r), runeSpan(c.typeSpan.Start+i, r))
+		}
+	}
+
+	// 4. A scope MUST consist of a noun describing a section of the codebase
+	for i, r := range c.scope {
+		if unicode.IsSpace(r) || unicode.IsControl(r) {
+			return newError(fmt.Errorf(errStrictScopeChar, r), runeSpan(c.scopeSpan.Start+i, r))
+		}
+	}
+
+	// 5. A description MUST immediately follow the colon and space after the type/scope prefix
+	if c.description == "" {
+		return newError(errDescEmpty, c.descriptionSpan)
+	}
+	if trimmed := strings.TrimLeftFunc(c.description, unicode.IsSpace); trimmed != c.description {
+		span := Span{Start: c.descriptionSpan.Start, End: c.descriptionSpan.Start + len(c.description) - len(trimmed)}
+		return newError(errDescLeadingSpace, span, Edit{Span: span})
+	}
+
+	// 12. a breaking change MUST consist of the uppercase text BREAKING CHANGE, followed by a colon, space.
+	// Breaking change tokens with other separators are no footers, so look for them in the whole message.
+	for _, m := range breakingSeparatorLine.FindAllStringSubmatchIndex(c.message, -1) {
+		if sep := c.message[m[2]:m[3]]; sep != footerSeparatorColon && m[0] > c.headerSpan.End {
+			sepSpan := Span{Start: m[2], End: m[3]}
+			return newError(fmt.Errorf(errBreakingSeparator, sep), sepSpan, Edit{Span: sepSpan, Text: footerSeparatorColon})
+		}
+	}
+
+	for _, n := range c.notes {
+		tokenSpan := Span{Start: n.span.Start, End: n.span.Start + len(n.token)}
+
+		// 12. a breaking change MUST consist of the uppercase text BREAKING CHANGE, followed by a colon, space
+		if upper := strings.ToUpper(n.token); !isBreakingToken(n.token) && isBreakingToken(upper) {
+			return newError(fmt.Errorf(errBreakingTokenCase, n.token), tokenSpan, Edit{Span: tokenSpan, Text: upper})
+		}
+
+		sepSpan := Span{Start: tokenSpan.End, End: tokenSpan.End + len(n.separator)}
+
+		// 8. followed by either a :<space> or <space># separator
+		if n.separator != footerSeparatorColon && n.separator != footerSeparatorHash {
+			return newError(fmt.Errorf(errFooterSeparator, n.separator, n.token), sepSpan)
+		}
+
+		// 8. Each footer MUST consist of a word token, followed by a separator, followed by a string value
+		if n.value == "" {
+			return newError(fmt.Errorf(errFooterValueEmpty, n.token), n.span)
+		}
+	}
+
+	return nil
+}
+
+// runeSpan returns the span of r at pos
+func runeSpan(pos int, r rune) Span {
+	return Span{Start: pos, End: pos + utf8.RuneLen(r)}
+}
+
+// isStrictTypeChar reports whether r may be part of a type noun, a letter
+// of any script
+func isStrictTypeChar(r rune) bool {
+	return unicode.IsLetter(r)
+}
@@ -0,0 +1,124 @@
+package parser
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const conformanceDir = "conformance"
+
+type conformanceCase struct {
+	Name string `json:"name"`
+	// Source is where the case comes from, the spec example or rule
+	Source  string `json:"source"`
+	Message string `json:"message"`
+	Valid   bool   `json:"valid"`
+	Commit  struct {
+		Type        string      `json:"type"`
+		Scope       string      `json:"scope"`
+		Description string      `json:"description"`
+		Body        string      `json:"body"`
+		Footer      string      `json:"footer"`
+		Notes       [][2]string `json:"notes"`
+		Breaking    bool        `json:"breaking"`
+	} `json:"commit"`
+}
+
+func TestConformanceV100(t *testing.T) {
+	runConformance(t, ModeStrictV100)
+}
+
+func TestModeForSpec(t *testing.T) {
+	m, err := ModeForSpec("v1.0.0")
+	if err != nil || m != ModeStrictV100 {
+		t.Errorf("ModeForSpec(v1.0.0) = %v, %v", m, err)
+	}
+
+	if _, err := ModeForSpec("0.9.0"); err == nil {
+		t.Error("ModeForSpec(0.9.0) passed without error")
+	}
+}
+
+func TestLenientAcceptsNonConforming(t *testing.T) {
+	var cases = []string{
+		"feat1: add something",
+		"fëat: add something",
+		"feat(user api): add something",
+		"feat: \n\nbody of a commit without description",
+		"feat: add something\n\nbreaking-change: everything",
+		"(x): y",
+		": y",
+		"!: y",
+	}
+
+	for _, msg := range cases {
+		if _, err := New().Parse(msg); err != nil {
+			t.Errorf("lenient parser failed for %q: %v", msg, err)
+		}
+	}
+}
+
+func runConformance(t *testing.T, mode Mode) {
+	cases, err := loadConformanceCases(mode.Spec())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := New(WithMode(mode))
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(innerT *testing.T) {
+			if tc.Source == "" {
+				innerT.Error("case has no source")
+			}
+
+			actual, err := p.Parse(tc.Message)
+			if !tc.Valid {
+				if err == nil {
+					innerT.Errorf("%s passed without error for %q", mode, tc.Message)
+				}
+				return
+			}
+
+			if err != nil {
+				innerT.Errorf("%s failed for %q: %v", mode, tc.Message, err)
+				return
+			}
+
+			expected := &Commit{
+				commitType:       tc.Commit.Type,
+				scope:            tc.Commit.Scope,
+				description:      tc.Commit.Description,
+				body:             tc.Commit.Body,
+				isBreakingChange: tc.Commit.Breaking,
+			}
+			for _, n := range tc.Commit.Notes {
+				expected.notes = append(expected.notes, newNote(n[0], n[1]))
+			}
+
+			if !compareCommit(innerT, actual, expected) {
+				innerT.Errorf("Commit not equal :\n\tExpected: %v,\n\tActual: %v", expected, actual)
+			}
+
+			if actual.Footer() != tc.Commit.Footer {
+				innerT.Errorf("Footer not equal, actual: %q, expected: %q", actual.Footer(), tc.Commit.Footer)
+			}
+		})
+	}
+}
+
+func loadConformanceCases(version string) ([]conformanceCase, error) {
+	out, err := os.ReadFile(filepath.Join(testDataDir, conformanceDir, "v"+version+".json"))
+	if err != nil {
+		return nil, err
+	}
+
+	var cases []conformanceCase
+	if err := json.Unmarshal(out, &cases); err != nil {
+		return nil, err
+	}
+	return cases, nil
+}
@@ -0,0 +1,15 @@
+#!/bin/sh
+# Regenerates the expected trailers of the fixtures with git interpret-trailers.
+#
+# default/*.txt use the default trailer.separators, separators/*.txt use ":=#".
+set -e
+
+cd "$(dirname "$0")"
+
+for f in default/*.txt; do
+	git interpret-trailers --parse <"$f" >"${f%.txt}.trailers"
+done
+
+for f in separators/*.txt; do
+	git -c trailer.separators=':=#' interpret-trailers --parse <"$f" >"${f%.txt}.trailers"
+done
@@ -0,0 +1,294 @@
+package parser
+
+import (
+	"strings"
+)
+
+const (
+	gitCommentChar       = '#'
+	gitDefaultSeparators = ":"
+)
+
+// gitGeneratedPrefixes are the trailers git itself adds to commit messages
+var gitGeneratedPrefixes = []string{"Signed-off-by: ", "(cherry picked from commit "}
+
+// WithGitTrailers makes the parser detect footers the way git interpret-trailers
+// does: only the last paragraph of the message can hold trailers, lines
+// starting with whitespace continue the previous trailer, and the paragraph
+// may contain other lines if at least a quarter of it are trailers and one
+// of them was generated by git, like Signed-off-by.
+//
+// separators has the same meaning as git's trailer.separators, every
+// character is a separator. It defaults to ":". Since git does not allow
+// whitespace in trailer tokens, "BREAKING CHANGE" is not a trailer in this
+// mode, a line starting with it still marks the commit as breaking change.
+func WithGitTrailers(separators string) Option {
+	return func(p *Parser) {
+		if separators == "" {
+			separators = gitDefaultSeparators
+		}
+		p.gitTrailerSeparators = separators
+	}
+}
+
+// lineSpan is the position of a line in the source, without the newline
+type lineSpan struct {
+	start, end int
+}
+
+// gitTrailerState emits the body and the trailers of the message
+func gitTrailerState(l *lexer) stateFunc {
+	lines := splitLines(l.source, l.currentPos)
+	lines = trimGitNonTrailerLines(l.source, lines)
+
+	blockStart := findGitTrailerBlock(l.source, lines, l.gitTrailerSeparators)
+
+	bodyEnd := len(l.source)
+	if blockStart < len(lines) {
+		bodyEnd = lines[blockStart].start
+	}
+
+	if bodyEnd > l.currentPos {
+		l.Seek(bodyEnd)
+		l.Emit(bodyToken)
+	}
+
+	inTrailer := false
+	for _, ln := range lines[blockStart:] {
+		l.Seek(ln.start)
+		l.Ignore()
+
+		line := l.source[ln.start:ln.end]
+
+		if line[0] == gitCommentChar {
+			inTrailer = false
+			continue
+		}
+
+		// whitespace continues the value of the previous trailer
+		if inTrailer && isGitSpace(line[0]) {
+			l.Seek(ln.end)
+			l.Emit(footerValueToken)
+			continue
+		}
+
+		sepPos := findGitSeparator(line, l.gitTrailerSeparators)
+		if sepPos < 1 {
+			inTrailer = false
+			continue
+		}
+
+		tok := strings.TrimRight(line[:sepPos], " \t")
+		l.Seek(ln.start + len(tok))
+		if isConfiguredBreakingToken(l, tok) {
+			l.Emit(footerBreakingKeyToken)
+		} else {
+			l.Emit(footerKeyToken)
+		}
+
+		valueStart := sepPos + 1
+		for valueStart < len(line) && isGitSpace(line[valueStart]) {
+			valueStart++
+		}
+		l.Seek(ln.start + valueStart)
+		l.Emit(footerDelimterToken)
+
+		l.Seek(ln.end)
+		l.Emit(footerValueToken)
+
+		inTrailer = true
+	}
+
+	return nil
+}
+
+// findGitTrailerBlock returns the index of the first line of the trailer
+// block, or len(lines) if there is none. It follows find_trailer_block_start
+// of git's trailer.c, lines start after the blank line following the title.
+func findGitTrailerBlock(src string, lines []lineSpan, separators string) int {
+	onlySpaces := true
+	recognizedPrefix := false
+	trailerLines, nonTrailerLines, possibleContinuationLines := 0, 0, 0
+
+	// index -1 is the blank line after the title
+	for i := len(lines) - 1; i >= -1; i-- {
+		line := ""
+		if i >= 0 {
+			line = src[lines[i].start:lines[i].end]
+		}
+
+		if line != "" && line[0] == gitCommentChar {
+			nonTrailerLines += possibleContinuationLines
+			possibleContinuationLines = 0
+			continue
+		}
+
+		if isGitBlankLine(line) {
+			if onlySpaces {
+				continue
+			}
+			nonTrailerLines += possibleContinuationLines
+			if recognizedPrefix && trailerLines*3 >= nonTrailerLines {
+				return i + 1
+			} else if trailerLines > 0 && nonTrailerLines == 0 {
+				return i + 1
+			}
+			return len(lines)
+		}
+		onlySpaces = false
+
+		if hasGitGeneratedPrefix(line) {
+			trailerLines++
+			possibleContinuationLines = 0
+			recognizedPrefix = true
+			continue
+		}
+
+		if findGitSeparator(line, separators) >= 1 && !isGitSpace(line[0]) {
+			trailerLines++
+			possibleContinuationLines = 0
+		} else if isGitSpace(line[0]) {
+			possibleContinuationLines++
+		} else {
+			nonTrailerLines++
+			nonTrailerLines += possibleContinuationLines
+			possibleContinuationLines = 0
+		}
+	}
+
+	return len(lines)
+}
+
+// findGitSeparator returns the position of the separator in a trailer line,
+// or -1. The token before it may only contain alphanumeric characters and
+// hyphens, followed by optional whitespace.
+func findGitSeparator(line, separators string) int {
+	whitespaceFound := false
+
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		if strings.IndexByte(separators, c) >= 0 {
+			return i
+		}
+		if !whitespaceFound && (isASCIIAlnum(c) || c == '-') {
+			continue
+		}
+		if i != 0 && (c == ' ' || c == '\t') {
+			whitespaceFound = true
+			continue
+		}
+		break
+	}
+
+	return -1
+}
+
+// trimGitNonTrailerLines removes the patch part starting at a "---" line,
+// and trailing blank and comment lines
+func trimGitNonTrailerLines(src string, lines []lineSpan) []lineSpan {
+	for i, ln := range lines {
+		line := src[ln.start:ln.end]
+		if strings.HasPrefix(line, "---") && (len(line) == 3 || isGitSpace(line[3])) {
+			lines = lines[:i]
+			break
+		}
+	}
+
+	for len(lines) > 0 {
+		line := src[lines[len(lines)-1].start:lines[len(lines)-1].end]
+		if !isGitBlankLine(line) && line[0] != gitCommentChar {
+			break
+		}
+		lines = lines[:len(lines)-1]
+	}
+
+	return lines
+}
+
+// splitLines returns the lines of src starting at pos
+func splitLines(src string, pos int) []lineSpan {
+	var lines []lineSpan
+
+	for pos < len(src) {
+		end := strings.IndexByte(src[pos:], '\n')
+		if end < 0 {
+			lines = append(lines, lineSpan{pos, len(src)})
+			break
+		}
+		lines = append(lines, lineSpan{pos, pos + end})
+		pos += end + 1
+	}
+
+	return lines
+}
+
+// unfoldGitValue collapses continuation lines into single spaces
+func unfoldGitValue(value string) string {
+	var b strings.Builder
+
+	for i := 0; i < len(value); i++ {
+		if value[i] != '\n' {
+			b.WriteByte(value[i])
+			continue
+		}
+		for i+1 < len(value) && isGitSpace(value[i+1]) {
+			i++
+		}
+		b.WriteByte(' ')
+	}
+
+	return strings.TrimSpace(b.String())
+}
+
+// hasBreakingChangeLine reports whether a line of text starts with a breaking change token
+func hasBreakingChangeLine(text string, tokens []string) bool {
+	for _, line := range strings.Split(text, "\n") {
+		for _, tok := range tokens {
+			if strings.HasPrefix(line, tok+footerSeparatorColon) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isConfiguredBreakingToken(l *lexer, tok string) bool {
+	for _, t := range l.breakingTokens {
+		if t == tok {
+			return true
+		}
+	}
+	return false
+}
+
+func hasGitGeneratedPrefix(line string) bool {
+	for _, prefix := range gitGeneratedPrefixes {
+		if strings.HasPrefix(line, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func isGitBlankLine(line string) bool {
+	for i := 0; i < len(line); i++ {
+		if !isGitSpace(line[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// isGitSpace matches the isspace of git's own ctype table
+func isGitSpace(c byte) bool {
+	switch c {
+	case ' ', '\t', '\n', '\r':
+		return true
+	default:
+		return false
+	}
+}
+
+func isASCIIAlnum(c byte) bool {
+	return ('0' <= c && c <= '9') || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
@@ -0,0 +1,117 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const trailersDir = "trailers"
+
+// fixtures are generated by testdata/trailers/generate.sh
+func TestParserGitTrailers(t *testing.T) {
+	var dirs = map[string]string{
+		"default":    "",
+		"separators": ":=#",
+	}
+
+	for dir, separators := range dirs {
+		files, err := filepath.Glob(filepath.Join(testDataDir, trailersDir, dir, "*.txt"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		p := New(WithGitTrailers(separators))
+
+		for _, file := range files {
+			file := file
+			t.Run(dir+"/"+filepath.Base(file), func(innerT *testing.T) {
+				msg, err := os.ReadFile(file)
+				if err != nil {
+					innerT.Fatal(err)
+				}
+				expected, err := os.ReadFile(strings.TrimSuffix(file, ".txt") + ".trailers")
+				if err != nil {
+					innerT.Fatal(err)
+				}
+
+				c, err := p.Parse(string(msg))
+				if err != nil {
+					innerT.Fatal(err)
+				}
+
+				actual := ""
+				for _, n := range c.Notes() {
+					actual += n.Token() + ": " + n.Value() + "\n"
+				}
+
+				if actual != string(expected) {
+					innerT.Errorf("trailers not equal to git interpret-trailers:\n\tExpected: %q,\n\tActual: %q", expected, actual)
+				}
+			})
+		}
+	}
+}
+
+func TestParserGitTrailersBody(t *testing.T) {
+	p := New(WithGitTrailers(""))
+
+	var cases = []struct {
+		message  string
+		body     string
+		footer   string
+		breaking bool
+	}{
+		{
+			message: "feat: add x\n\nNote: part of the body\nbecause not every line is a trailer.\n\nReviewed-by: Z",
+			body:    "Note: part of the body\nbecause not every line is a trailer.",
+			footer:  "Reviewed-by: Z",
+		},
+		{
+			message: "feat: add x\n\nReviewed-by: Z\n\nthe last paragraph",
+			body:    "Reviewed-by: Z\n\nthe last paragraph",
+		},
+		{
+			message: "feat: add x\n\nCo-authored-by: Jane Doe\n  <jane@example.com>",
+			footer:  "Co-authored-by: Jane Doe\n  <jane@example.com>",
+		},
+		{
+			message:  "feat: add x\n\nBREAKING CHANGE: removed y\nRefs: #1",
+			body:     "BREAKING CHANGE: removed y\nRefs: #1",
+			breaking: true,
+		},
+		{
+			message:  "feat: add x\n\nBREAKING-CHANGE: removed y",
+			footer:   "BREAKING-CHANGE: removed y",
+			breaking: true,
+		},
+	}
+
+	for _, tc := range cases {
+		c, err := p.Parse(tc.message)
+		if err != nil {
+			t.Errorf("Parse(%q) failed: %v", tc.message, err)
+			continue
+		}
+		if c.Body() != tc.body || c.Footer() != tc.footer || c.IsBreakingChange() != tc.breaking {
+			t.Errorf("Parse(%q): body %q, footer %q, breaking %v", tc.message, c.Body(), c.Footer(), c.IsBreakingChange())
+		}
+	}
+}
+
+func TestParserGitTrailersSeparator(t *testing.T) {
+	p := New(WithGitTrailers(":="))
+
+	c, err := p.Parse("feat: add x\n\nReviewed-by = Z\nRefs:#1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var expected = []string{"Reviewed-by = Z", "Refs:#1"}
+	for i, n := range c.Notes() {
+		if n.String() != expected[i] {
+			t.Errorf("note %d: got %q, expected %q", i, n.String(), expected[i])
+		}
+	}
+}
@@ -0,0 +1,192 @@
+// Package width measures the display width of text in terminals and editors,
+// counting East Asian wide characters and emoji as two columns and combining
+// marks as none
+package width
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// runeRange is an inclusive range of runes
+type runeRange struct {
+	lo, hi rune
+}
+
+// wide are the East Asian Wide and Fullwidth ranges, and emoji with default
+// emoji presentation
+var wide = []runeRange{
+	{0x1100, 0x115F}, {0x231A, 0x231B}, {0x2329, 0x232A}, {0x23E9, 0x23EC},
+	{0x23F0, 0x23F0}, {0x23F3, 0x23F3}, {0x25FD, 0x25FE}, {0x2614, 0x2615},
+	{0x2648, 0x2653}, {0x267F, 0x267F}, {0x2693, 0x2693}, {0x26A1, 0x26A1},
+	{0x26AA, 0x26AB}, {0x26BD, 0x26BE}, {0x26C4, 0x26C5}, {0x26CE, 0x26CE},
+	{0x26D4, 0x26D4}, {0x26EA, 0x26EA}, {0x26F2, 0x26F3}, {0x26F5, 0x26F5},
+	{0x26FA, 0x26FA}, {0x26FD, 0x26FD}, {0x2705, 0x2705}, {0x270A, 0x270B},
+	{0x2728, 0x2728}, {0x274C, 0x274C}, {0x274E, 0x274E}, {0x2753, 0x2755},
+	{0x2757, 0x2757}, {0x2795, 0x2797}, {0x27B0, 0x27B0}, {0x27BF, 0x27BF},
+	{0x2B1B, 0x2B1C}, {0x2B50, 0x2B50}, {0x2B55, 0x2B55}, {0x2E80, 0x303E},
+	{0x3041, 0x33FF}, {0x3400, 0x4DBF}, {0x4E00, 0x9FFF}, {0xA000, 0xA4CF},
+	{0xA960, 0xA97F}, {0xAC00, 0xD7A3}, {0xF900, 0xFAFF}, {0xFE10, 0xFE19},
+	{0xFE30, 0xFE6F}, {0xFF00, 0xFF60}, {0xFFE0, 0xFFE6}, {0x16FE0, 0x16FE4},
+	{0x17000, 0x18CFF}, {0x1B000, 0x1B2FF}, {0x1F004, 0x1F004}, {0x1F0CF, 0x1F0CF},
+	{0x1F18E, 0x1F18E}, {0x1F191, 0x1F19A}, {0x1F200, 0x1F251}, {0x1F300, 0x1F64F},
+	{0x1F680, 0x1F6FF}, {0x1F7E0, 0x1F7EB}, {0x1F90C, 0x1F9FF}, {0x1FA70, 0x1FAFF},
+	{0x20000, 0x2FFFD}, {0x30000, 0x3FFFD},
+}
+
+// TabWidth is the distance of tab stops, like in most editors configured
+// for commit messages
+const TabWidth = 4
+
+const (
+	zeroWidthJoiner     = '\u200d'
+	emojiPresentation   = '\ufe0f'
+	regionalIndicatorLo = 0x1F1E6
+	regionalIndicatorHi = 0x1F1FF
+)
+
+// Rune returns the number of columns r occupies on its own: TabWidth for
+// tabs at a tab stop, 0 for other control characters, combining marks and other zero width
+// characters, 2 for wide characters and 1 otherwise
+func Rune(r rune) int {
+	switch {
+	case r == '\t':
+		return TabWidth
+	case r == 0 || r < 0x20 || (r >= 0x7F && r < 0xA0):
+		return 0
+	case isZeroWidth(r):
+		return 0
+	case inRanges(r, wide):
+		return 2
+	default:
+		return 1
+	}
+}
+
+// String returns the number of columns s occupies. Every grapheme cluster
+// takes the width of its first rune, emoji sequences like flags, skin tones
+// or ZWJ sequences two columns. Tabs advance to the next tab stop, counted
+// from the start of s.
+func String(s string) int {
+	n := 0
+	for len(s) > 0 {
+		size := nextGrapheme(s)
+		if s[0] == '\t' {
+			n += TabWidth - n%TabWidth
+		} else {
+			n += graphemeWidth(s[:size])
+		}
+		s = s[size:]
+	}
+	return n
+}
+
+// Graphemes splits s into user-perceived characters, like "e" followed by
+// a combining accent or an emoji with skin tone modifier
+func Graphemes(s string) []string {
+	var graphemes []string
+	for len(s) > 0 {
+		size := nextGrapheme(s)
+		graphemes = append(graphemes, s[:size])
+		s = s[size:]
+	}
+	return graphemes
+}
+
+// GraphemeCount returns the number of user-perceived characters in s
+func GraphemeCount(s string) int {
+	n := 0
+	for len(s) > 0 {
+		s = s[nextGrapheme(s):]
+		n++
+	}
+	return n
+}
+
+// nextGrapheme returns the size in bytes of the first grapheme cluster of s.
+// It implements the rules of UAX #29 relevant for commit messages: CR LF,
+// extending and spacing marks, ZWJ sequences and regional indicator pairs.
+func nextGrapheme(s string) int {
+	first, size := utf8.DecodeRuneInString(s)
+
+	if first == '\r' && len(s) > 1 && s[1] == '\n' {
+		return 2
+	}
+	if first < 0x20 || first == 0x7F {
+		return size
+	}
+
+	prev := first
+	for size < len(s) {
+		r, n := utf8.DecodeRuneInString(s[size:])
+
+		switch {
+		case isExtend(r):
+		case prev == zeroWidthJoiner:
+		case isRegionalIndicator(first) && isRegionalIndicator(r) && size == utf8.RuneLen(first):
+		default:
+			return size
+		}
+
+		prev = r
+		size += n
+	}
+
+	return size
+}
+
+func graphemeWidth(g string) int {
+	first, size := utf8.DecodeRuneInString(g)
+
+	switch {
+	case isRegionalIndicator(first):
+		return 2
+	case size < len(g) && containsRune(g[size:], emojiPresentation):
+		return 2
+	}
+
+	return Rune(first)
+}
+
+// isExtend reports whether r continues the previous grapheme cluster
+func isExtend(r rune) bool {
+	return unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc) ||
+		r == zeroWidthJoiner ||
+		(r >= 0x1F3FB && r <= 0x1F3FF) || // emoji skin tone modifiers
+		(r >= 0xE0020 && r <= 0xE007F) || // tags
+		(r >= 0x1160 && r <= 0x11FF) // hangul vowels and trailing consonants
+}
+
+func isZeroWidth(r rune) bool {
+	return unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf) ||
+		(r >= 0x1160 && r <= 0x11FF)
+}
+
+func isRegionalIndicator(r rune) bool {
+	return r >= regionalIndicatorLo && r <= regionalIndicatorHi
+}
+
+func inRanges(r rune, ranges []runeRange) bool {
+	lo, hi := 0, len(ranges)
+	for lo < hi {
+		mid := (lo + hi) / 2
+		switch {
+		case r < ranges[mid].lo:
+			hi = mid
+		case r > ranges[mid].hi:
+			lo = mid + 1
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+func containsRune(s string, r rune) bool {
+	for _, c := range s {
+		if c == r {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,75 @@
+package width
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestString(t *testing.T) {
+	var cases = map[string]int{
+		"":                  0,
+		"feat: add x":       11,
+		"äöü":               3,
+		"e\u0301":           1,
+		"修复登录":              8,
+		"ｆｕｌｌ":              8,
+		"한국어":               6,
+		"🎉":                 2,
+		"👍🏽":                2,
+		"👩\u200d💻":          2,
+		"🇩🇪":                2,
+		"❤\ufe0f":           2,
+		"❤":                 1,
+		"a\u200bb":          2,
+		"\tx":               TabWidth + 1,
+		"ab\tc":             TabWidth + 1,
+		"abcd\tx":           2*TabWidth + 1,
+		"修\t\tx":            2*TabWidth + 1,
+		"fix: 修复 🎉 release": 20,
+	}
+
+	for s, expected := range cases {
+		if actual := String(s); actual != expected {
+			t.Errorf("String(%q) = %d, expected %d", s, actual, expected)
+		}
+	}
+}
+
+func TestGraphemes(t *testing.T) {
+	var cases = map[string][]string{
+		"abc":       {"a", "b", "c"},
+		"e\u0301x":  {"e\u0301", "x"},
+		"👍🏽!":       {"👍🏽", "!"},
+		"👩\u200d💻👩": {"👩\u200d💻", "👩"},
+		"🇩🇪🇫🇷":      {"🇩🇪", "🇫🇷"},
+		"a\r\nb":    {"a", "\r\n", "b"},
+		"":          nil,
+	}
+
+	for s, expected := range cases {
+		if actual := Graphemes(s); !reflect.DeepEqual(actual, expected) {
+			t.Errorf("Graphemes(%q) = %q, expected %q", s, actual, expected)
+		}
+		if actual := GraphemeCount(s); actual != len(expected) {
+			t.Errorf("GraphemeCount(%q) = %d, expected %d", s, actual, len(expected))
+		}
+	}
+}
+
+func TestRune(t *testing.T) {
+	var cases = map[rune]int{
+		'a':      1,
+		'\t':     TabWidth,
+		'\n':     0,
+		'\u0301': 0,
+		'中':      2,
+		'🎉':      2,
+		'\u3000': 2,
+	}
+
+	for r, expected := range cases {
+		if actual := Rune(r); actual != expected {
+			t.Errorf("Rune(%q) = %d, expected %d", r, actual, expected)
+		}
+	}
+}
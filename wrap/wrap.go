@@ -0,0 +1,374 @@
+// Package wrap reflows the body and footer of commit messages to a maximum
+// display width
+package wrap
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/conventionalcommit/parser/width"
+)
+
+var (
+	// listItem matches the marker of bullet and numbered list items, like "- " or "1. "
+	listItem = regexp.MustCompile(`^[ \t]*([-*+]|\d+[.)])[ \t]+`)
+	// fence matches the start and end of fenced code blocks
+	fence = regexp.MustCompile("^[ \t]*(```|~~~)")
+)
+
+// breakingToken is the footer token with a space
+const breakingToken = "BREAKING CHANGE"
+
+// Option configures how footer notes are recognized, which should match
+// the options of the parser the message is parsed with
+type Option func(*options)
+
+type options struct {
+	separators    []string
+	gitSeparators string
+}
+
+// WithFooterSeparators adds separators between footer tokens and values,
+// like parser.WithFooterSeparators. ": " and " #" are always recognized.
+func WithFooterSeparators(separators ...string) Option {
+	return func(o *options) {
+		o.separators = append(o.separators, separators...)
+	}
+}
+
+// WithGitTrailers also recognizes footer notes the way git interpret-trailers
+// does, like parser.WithGitTrailers: tokens of letters, digits and hyphens,
+// followed by optional whitespace and one of the separator characters. The
+// separators default to ":".
+func WithGitTrailers(separators string) Option {
+	return func(o *options) {
+		if separators == "" {
+			separators = ":"
+		}
+		o.gitSeparators = separators
+	}
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{separators: []string{": ", " #"}}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	// prefer the longest separator, so ": " wins over ":"
+	sort.SliceStable(o.separators, func(i, j int) bool {
+		return len(o.separators[i]) > len(o.separators[j])
+	})
+	return o
+}
+
+// noteStart returns the token and the prefix of a line starting a footer
+// note, like "Refs" and "Refs: " of "Refs: #1"
+func (o *options) noteStart(line string) (token, prefix string, ok bool) {
+	n := 0
+	if strings.HasPrefix(line, breakingToken) {
+		n = len(breakingToken)
+	} else {
+		for n < len(line) {
+			r, size := utf8.DecodeRuneInString(line[n:])
+			if !unicode.IsLetter(r) && !unicode.IsNumber(r) && r != '-' {
+				break
+			}
+			n += size
+		}
+	}
+
+	if n > 0 {
+		for _, sep := range o.separators {
+			if strings.HasPrefix(line[n:], sep) {
+				return line[:n], line[:n+len(sep)], true
+			}
+		}
+	}
+
+	if o.gitSeparators != "" {
+		if end := gitSeparatorEnd(line, o.gitSeparators); end > 0 {
+			value := strings.TrimLeft(line[end:], " \t")
+			return strings.TrimRight(line[:end-1], " \t"), line[:len(line)-len(value)], true
+		}
+	}
+
+	return "", "", false
+}
+
+// isNoteStart reports whether line starts a footer note
+func (o *options) isNoteStart(line string) bool {
+	_, _, ok := o.noteStart(line)
+	return ok
+}
+
+// gitSeparatorEnd returns the position after the separator of a git trailer
+// line, or -1. The token before it may only contain ASCII letters, digits and
+// hyphens, followed by optional whitespace.
+func gitSeparatorEnd(line, separators string) int {
+	n := 0
+	for n < len(line) && (isASCIIAlnum(line[n]) || line[n] == '-') {
+		n++
+	}
+	if n == 0 {
+		return -1
+	}
+
+	rest := strings.TrimLeft(line[n:], " \t")
+	if rest == "" || strings.IndexByte(separators, rest[0]) < 0 {
+		return -1
+	}
+	return len(line) - len(rest) + 1
+}
+
+func isASCIIAlnum(c byte) bool {
+	return ('0' <= c && c <= '9') || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
+
+// defaultIndent indents continuation lines of footer notes without existing
+// continuation lines. Leading whitespace keeps them from starting a new note.
+const defaultIndent = " "
+
+// Body reflows the paragraphs of a commit body to lines of at most limit
+// columns. Words longer than limit, like URLs, are never broken. Bullet and
+// numbered list items are wrapped with their continuation lines aligned to
+// the item text. Fenced and indented code blocks, quotes, comments and other
+// indented lines are kept as they are. Lines are not started like footer
+// notes, which would end the body when parsed. A limit below 1 disables
+// wrapping.
+func Body(text string, limit int, opts ...Option) string {
+	if limit < 1 {
+		return text
+	}
+	o := newOptions(opts)
+
+	var out []string
+	var para *paragraph
+	inFence := false
+
+	flush := func() {
+		if para != nil {
+			out = append(out, para.fill(limit, o)...)
+			para = nil
+		}
+	}
+
+	for _, line := range strings.Split(text, "\n") {
+		switch {
+		case fence.MatchString(line):
+			flush()
+			inFence = !inFence
+			out = append(out, line)
+		case inFence:
+			out = append(out, line)
+		case strings.TrimSpace(line) == "":
+			flush()
+			out = append(out, line)
+		case listItem.MatchString(line):
+			flush()
+			marker := listItem.FindString(line)
+			para = &paragraph{
+				prefix: marker,
+				indent: strings.Repeat(" ", width.String(marker)),
+				text:   line[len(marker):],
+			}
+		case para != nil && para.isContinuation(line):
+			para.text += " " + strings.TrimSpace(line)
+		case isVerbatim(line):
+			flush()
+			out = append(out, line)
+		default:
+			flush()
+			para = &paragraph{text: line}
+		}
+	}
+	flush()
+
+	return strings.Join(out, "\n")
+}
+
+// Footer reflows the notes of a commit footer to lines of at most limit
+// columns. Continuation lines keep the indentation of the first existing
+// continuation line of the note, or are indented by a space. Notes with
+// tokens ending in "-by", like Signed-off-by, are kept as they are. A limit
+// below 1 disables wrapping.
+func Footer(text string, limit int, opts ...Option) string {
+	if limit < 1 {
+		return text
+	}
+	o := newOptions(opts)
+
+	var out []string
+	var note []string
+
+	flush := func() {
+		if len(note) > 0 {
+			out = append(out, fillNote(note, limit, o)...)
+			note = nil
+		}
+	}
+
+	for _, line := range strings.Split(text, "\n") {
+		start := o.isNoteStart(line)
+		if start {
+			flush()
+		}
+		if len(note) == 0 && !start {
+			out = append(out, line)
+			continue
+		}
+		note = append(note, line)
+	}
+	flush()
+
+	return strings.Join(out, "\n")
+}
+
+// fillNote wraps the lines of a footer note
+func fillNote(lines []string, limit int, o *options) []string {
+	token, prefix, _ := o.noteStart(lines[0])
+	if strings.HasSuffix(token, "-by") {
+		return lines
+	}
+
+	indent := defaultIndent
+	if len(lines) > 1 {
+		if trimmed := strings.TrimLeft(lines[1], " \t"); trimmed != lines[1] {
+			indent = lines[1][:len(lines[1])-len(trimmed)]
+		}
+	}
+
+	text := lines[0][len(prefix):]
+	for _, line := range lines[1:] {
+		text += " " + strings.TrimSpace(line)
+	}
+
+	p := paragraph{prefix: prefix, indent: indent, text: text}
+	return p.fill(limit, o)
+}
+
+// paragraph is text to fill into lines, with a prefix for the first line
+// like a list marker, and indent for the following lines
+type paragraph struct {
+	prefix string
+	indent string
+	text   string
+}
+
+// isContinuation reports whether line continues the paragraph
+func (p *paragraph) isContinuation(line string) bool {
+	if p.indent == "" {
+		return !isVerbatim(line)
+	}
+	return strings.HasPrefix(line, p.indent) && !isVerbatim(line[len(p.indent):])
+}
+
+// fill fills the words of the paragraph greedily into lines
+func (p *paragraph) fill(limit int, o *options) []string {
+	words := strings.Fields(p.text)
+	if len(words) == 0 {
+		return []string{strings.TrimRight(p.prefix, " \t")}
+	}
+
+	var lines [][]string
+	line := []string{words[0]}
+	lineWidth := width.String(p.prefix) + width.String(words[0])
+	indentWidth := width.String(p.indent)
+
+	for _, w := range words[1:] {
+		ww := width.String(w)
+		if lineWidth+1+ww > limit {
+			lines = append(lines, line)
+			line = []string{w}
+			lineWidth = indentWidth + ww
+			continue
+		}
+		line = append(line, w)
+		lineWidth += 1 + ww
+	}
+	lines = append(lines, line)
+
+	if p.indent == "" {
+		lines = o.avoidNoteStarts(lines, limit)
+	}
+
+	out := make([]string, len(lines))
+	for i, words := range lines {
+		lead := p.indent
+		if i == 0 {
+			lead = p.prefix
+		}
+		out[i] = lead + strings.Join(words, " ")
+	}
+	return out
+}
+
+// avoidNoteStarts moves words between unindented lines, so that no line but
+// the first starts like a footer note and would end the body when parsed.
+// Lines that grow beyond limit pass their last words on to the next line.
+func (o *options) avoidNoteStarts(lines [][]string, limit int) [][]string {
+	for i := 1; i < len(lines); i++ {
+		if !o.isNoteStart(strings.Join(lines[i], " ")) {
+			lines = o.capLine(lines, i, limit)
+			continue
+		}
+
+		prev := lines[i-1]
+		if len(prev) > 1 {
+			lines[i] = append([]string{prev[len(prev)-1]}, lines[i]...)
+			lines[i-1] = prev[:len(prev)-1]
+			lines = o.capLine(lines, i, limit)
+			continue
+		}
+
+		lines[i-1] = append(prev, lines[i]...)
+		lines = append(lines[:i], lines[i+1:]...)
+		i--
+		lines = o.capLine(lines, i, limit)
+	}
+	return lines
+}
+
+// capLine moves the last words of line i to the start of the next line
+// while it is wider than limit, unless the next line would start like a
+// footer note
+func (o *options) capLine(lines [][]string, i, limit int) [][]string {
+	for len(lines[i]) > 1 && width.String(strings.Join(lines[i], " ")) > limit {
+		last := lines[i][len(lines[i])-1]
+
+		var next []string
+		if i+1 < len(lines) {
+			next = lines[i+1]
+		}
+		next = append([]string{last}, next...)
+		if o.isNoteStart(strings.Join(next, " ")) {
+			break
+		}
+
+		lines[i] = lines[i][:len(lines[i])-1]
+		if i+1 < len(lines) {
+			lines[i+1] = next
+		} else {
+			lines = append(lines, next)
+		}
+	}
+	return lines
+}
+
+// isVerbatim reports whether a line must not be reflowed, like indented
+// code, quotes and comments
+func isVerbatim(line string) bool {
+	switch {
+	case line == "":
+		return false
+	case line[0] == ' ' || line[0] == '\t':
+		return true
+	case line[0] == '>' || line[0] == '#' || line[0] == '|':
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,162 @@
+package wrap
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBody(t *testing.T) {
+	var cases = []struct {
+		text     string
+		limit    int
+		expected string
+	}{
+		{
+			"the quick brown fox jumps over the lazy dog",
+			20,
+			"the quick brown fox\njumps over the lazy\ndog",
+		},
+		{
+			"short\nlines are\njoined",
+			80,
+			"short lines are joined",
+		},
+		{
+			"first paragraph\n\nsecond paragraph is longer",
+			16,
+			"first paragraph\n\nsecond paragraph\nis longer",
+		},
+		{
+			"- a bullet item that is too long\n- short\n  continued\n10. numbered item wraps",
+			20,
+			"- a bullet item that\n  is too long\n- short continued\n10. numbered item\n    wraps",
+		},
+		{
+			"see https://example.com/a/very/long/url/that/is/not/broken for details",
+			20,
+			"see\nhttps://example.com/a/very/long/url/that/is/not/broken\nfor details",
+		},
+		{
+			"code:\n\n    func main() { println(\"this line is long\") }\n\n```\nfenced code is kept as it is\n```",
+			10,
+			"code:\n\n    func main() { println(\"this line is long\") }\n\n```\nfenced code is kept as it is\n```",
+		},
+		{
+			"修复 登录 页面 的 问题",
+			10,
+			"修复 登录\n页面 的\n问题",
+		},
+		{
+			"this change fixes the bug Refs: #1 and more",
+			26,
+			"this change fixes the\nbug Refs: #1 and more",
+		},
+		{
+			"this change sets the Größe: 12 and more",
+			20,
+			"this change sets\nthe Größe: 12 and\nmore",
+		},
+		{
+			"unchanged",
+			0,
+			"unchanged",
+		},
+	}
+
+	for i, tc := range cases {
+		if actual := Body(tc.text, tc.limit); actual != tc.expected {
+			t.Errorf("case#%d: Body(%q, %d) =\n%s\nexpected\n%s", i, tc.text, tc.limit, actual, tc.expected)
+		}
+	}
+}
+
+func TestFooter(t *testing.T) {
+	var cases = []struct {
+		text     string
+		limit    int
+		expected string
+	}{
+		{
+			"BREAKING CHANGE: the configuration file format changed completely\nRefs: #1",
+			30,
+			"BREAKING CHANGE: the\n configuration file format\n changed completely\nRefs: #1",
+		},
+		{
+			"BREAKING CHANGE: the configuration\n    file format changed",
+			30,
+			"BREAKING CHANGE: the\n    configuration file format\n    changed",
+		},
+		{
+			"Signed-off-by: Jane Doe With A Very Long Name <jane@example.com>",
+			20,
+			"Signed-off-by: Jane Doe With A Very Long Name <jane@example.com>",
+		},
+	}
+
+	for i, tc := range cases {
+		if actual := Footer(tc.text, tc.limit); actual != tc.expected {
+			t.Errorf("case#%d: Footer(%q, %d) =\n%s\nexpected\n%s", i, tc.text, tc.limit, actual, tc.expected)
+		}
+	}
+}
+
+func TestSeparators(t *testing.T) {
+	var cases = []struct {
+		text     string
+		limit    int
+		opts     []Option
+		expected string
+	}{
+		{
+			"the default value is now Timeout=30 for all clients",
+			24,
+			nil,
+			"the default value is now\nTimeout=30 for all\nclients",
+		},
+		{
+			"the default value is now Timeout=30 for all clients",
+			24,
+			[]Option{WithFooterSeparators("=")},
+			"the default value is\nnow Timeout=30 for all\nclients",
+		},
+		{
+			"the default value is now Timeout = 30 for all clients",
+			24,
+			[]Option{WithGitTrailers("=")},
+			"the default value is\nnow Timeout = 30 for all\nclients",
+		},
+	}
+
+	for i, tc := range cases {
+		actual := Body(tc.text, tc.limit, tc.opts...)
+		if actual != tc.expected {
+			t.Errorf("case#%d: Body(%q, %d) =\n%s\nexpected\n%s", i, tc.text, tc.limit, actual, tc.expected)
+		}
+		for _, line := range strings.Split(actual, "\n") {
+			if len(line) > tc.limit {
+				t.Errorf("case#%d: line %q is longer than %d", i, line, tc.limit)
+			}
+		}
+	}
+
+	footer := "Timeout=the default timeout of all clients\nRefs #1"
+	expected := "Timeout=the default\n timeout of all clients\nRefs #1"
+	if actual := Footer(footer, 24, WithFooterSeparators("="), WithGitTrailers("#")); actual != expected {
+		t.Errorf("Footer(%q) =\n%s\nexpected\n%s", footer, actual, expected)
+	}
+}
+
+func TestBodyIdempotent(t *testing.T) {
+	text := "- a bullet item that is too long\n  continued here\n\nplain text that is much too long for the limit"
+
+	once := Body(text, 20)
+	if twice := Body(once, 20); twice != once {
+		t.Errorf("Body is not idempotent:\n%s\n\n%s", once, twice)
+	}
+
+	for _, line := range strings.Split(once, "\n") {
+		if len(line) > 20 {
+			t.Errorf("line %q is longer than 20", line)
+		}
+	}
+}